@@ -17,15 +17,20 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
+const (
+	ErrDeadlineExpired = "transaction deadline expired"
+	DeadlineArgPrefix  = "deadline:"
+)
+
 func (cc *ChainCode) checkAuthIfNeeds( //nolint:gocognit,funlen
 	stub shim.ChaincodeStubInterface,
 	method *Fn,
 	fn string,
 	args []string,
 	_ bool, // check
-) (*pb.Address, []string, uint64, error) {
+) (*pb.Address, []string, uint64, int64, error) {
 	if !method.needsAuth {
-		return nil, args, 0, nil
+		return nil, args, 0, 0, nil
 	}
 	total := len(args)
 	argMethodLen := len(method.in)
@@ -38,50 +43,61 @@ func (cc *ChainCode) checkAuthIfNeeds( //nolint:gocognit,funlen
 	authPos := argMethodLen + 4 //nolint:gomnd    // + reqId - 0, cc - 1, ch - 2, nonce - argMethodLen+3
 
 	if total < authPos {
-		return nil, nil, 0, errors.New("incorrect number of arguments")
+		return nil, nil, 0, 0, errors.New("incorrect number of arguments")
+	}
+
+	// после nonce может лежать подписанный deadline в виде "deadline:<unix seconds>"
+	var deadline int64
+	if total > authPos && strings.HasPrefix(args[authPos], DeadlineArgPrefix) {
+		var err error
+		deadline, err = strconv.ParseInt(strings.TrimPrefix(args[authPos], DeadlineArgPrefix), 10, 64) //nolint:gomnd
+		if err != nil {
+			return nil, nil, 0, 0, fmt.Errorf("incorrect deadline: %w", err)
+		}
+		authPos++
 	}
 
 	spr, err := stub.GetSignedProposal()
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, 0, err
 	}
 	proposal := &peer.Proposal{}
 	if err = proto.Unmarshal(spr.ProposalBytes, proposal); err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, 0, err
 	}
 	payload := &peer.ChaincodeProposalPayload{}
 	if err = proto.Unmarshal(proposal.Payload, payload); err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, 0, err
 	}
 	input := &peer.ChaincodeInvocationSpec{}
 	if err = proto.Unmarshal(payload.Input, input); err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, 0, err
 	}
 
 	if input.ChaincodeSpec == nil ||
 		input.ChaincodeSpec.ChaincodeId == nil ||
 		chaincodeName != input.ChaincodeSpec.ChaincodeId.Name {
-		return nil, nil, 0, errors.New("incorrect chaincode")
+		return nil, nil, 0, 0, errors.New("incorrect chaincode")
 	}
 
 	if channelName != stub.GetChannelID() {
-		return nil, nil, 0, errors.New("incorrect channel")
+		return nil, nil, 0, 0, errors.New("incorrect channel")
 	}
 
 	if len(args[authPos:])%2 != 0 {
-		return nil, nil, 0, errors.New("incorrect number of keys or signs")
+		return nil, nil, 0, 0, errors.New("incorrect number of keys or signs")
 	}
 
 	signers := (total - authPos) / 2 //nolint:gomnd
 	if signers == 0 {
-		return nil, nil, 0, errors.New("should be signed")
+		return nil, nil, 0, 0, errors.New("should be signed")
 	}
 
 	message := sha3.Sum256([]byte(fn + strings.Join(args[:len(args)-signers], "")))
 
 	acl, err := helpers.CheckACL(stub, args[authPos:authPos+signers])
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, 0, err
 	}
 	N := 1 // for single sign
 	if signers > 1 {
@@ -99,38 +115,48 @@ func (cc *ChainCode) checkAuthIfNeeds( //nolint:gocognit,funlen
 		key := base58.Decode(args[i])
 		sign := base58.Decode(args[i+signers])
 		if len(key) != ed25519.PublicKeySize || !ed25519.Verify(key, message[:], sign) {
-			return nil, nil, 0, errors.New("incorrect signature")
+			return nil, nil, 0, 0, errors.New("incorrect signature")
 		}
 
 		N--
 	}
 
 	if N > 0 {
-		return nil, nil, 0, errors.New("signature policy isn't satisfied")
+		return nil, nil, 0, 0, errors.New("signature policy isn't satisfied")
+	}
+
+	if deadline != 0 {
+		ts, err := stub.GetTxTimestamp()
+		if err != nil {
+			return nil, nil, 0, 0, err
+		}
+		if ts.Seconds > deadline {
+			return nil, nil, 0, 0, errors.New(ErrDeadlineExpired)
+		}
 	}
 
 	if acl.Account != nil && acl.Account.BlackListed {
-		return nil, nil, 0, fmt.Errorf("address %s is blacklisted", (*types.Address)(acl.Address.Address).String())
+		return nil, nil, 0, 0, fmt.Errorf("address %s is blacklisted", (*types.Address)(acl.Address.Address).String())
 	}
 	if acl.Account != nil && acl.Account.GrayListed {
-		return nil, nil, 0, fmt.Errorf("address %s is graylisted", (*types.Address)(acl.Address.Address).String())
+		return nil, nil, 0, 0, fmt.Errorf("address %s is graylisted", (*types.Address)(acl.Address.Address).String())
 	}
 
 	if err = helpers.AddAddrIfChanged(stub, acl.Address); err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, 0, err
 	}
 
 	nonce, err := strconv.ParseUint(nonceStr, 10, 64) //nolint:gomnd
 	if err != nil {
-		return nil, nil, 0, err
+		return nil, nil, 0, 0, err
 	}
 
 	// Проверим нонс по старому
 	if cc.nonceTTL == 0 {
 		if err = cc.nonceCheckFn(stub, types.NewSenderFromAddr((*types.Address)(acl.Address.Address)), nonce); err != nil {
-			return nil, nil, 0, fmt.Errorf("incorrect nonce: %w", err)
+			return nil, nil, 0, 0, fmt.Errorf("incorrect nonce: %w", err)
 		}
 	}
 
-	return acl.Address.Address, args[3 : 3+argMethodLen], nonce, nil
+	return acl.Address.Address, args[3 : 3+argMethodLen], nonce, deadline, nil
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
@@ -23,6 +24,10 @@ const (
 	StateKeyLockedTokenBalance
 	StateKeyLockedAllowedBalance
 	StateKeyPassedNonce // Этот префикс используется для нонсов у US
+	StateKeyGivenBalanceLimit
+	StateKeyAllowance
+	StateKeyGivenBalanceReserved
+	StateKeyVestingBalance
 )
 
 func balanceGet(stub shim.ChaincodeStubInterface, tokenType StateKey, addr *types.Address, path ...string) (string, *big.Int, error) {
@@ -49,6 +54,9 @@ func balanceSub(stub shim.ChaincodeStubInterface, tokenType StateKey, addr *type
 	if balance.Cmp(amount) < 0 {
 		return errors.New("insufficient funds to process")
 	}
+	if err = holdersUpdate(stub, tokenType, addr, new(big.Int).Neg(amount), path); err != nil {
+		return err
+	}
 	return stub.PutState(key, new(big.Int).Sub(balance, amount).Bytes())
 }
 
@@ -60,6 +68,9 @@ func balanceAdd(stub shim.ChaincodeStubInterface, tokenType StateKey, addr *type
 	if err != nil {
 		return err
 	}
+	if err = holdersUpdate(stub, tokenType, addr, amount, path); err != nil {
+		return err
+	}
 	return stub.PutState(key, new(big.Int).Add(balance, amount).Bytes())
 }
 
@@ -71,6 +82,9 @@ func balanceTransfer(stub shim.ChaincodeStubInterface, tokenType StateKey, from
 }
 
 func (bc *BaseContract) tokenBalanceSub(address *types.Address, amount *big.Int, token string) error {
+	if err := bc.freezeCheck(address, nil); err != nil {
+		return err
+	}
 	parts := strings.Split(token, "_")
 	if len(parts) > 1 {
 		return balanceSub(bc.stub, StateKeyTokenBalance, address, amount, parts[len(parts)-1])
@@ -78,6 +92,7 @@ func (bc *BaseContract) tokenBalanceSub(address *types.Address, amount *big.Int,
 	return balanceSub(bc.stub, StateKeyTokenBalance, address, amount)
 }
 
+// tokenBalanceAdd adds the balance without freeze and transfer rules checks, it's used to refund swaps and multiswaps
 func (bc *BaseContract) tokenBalanceAdd(address *types.Address, amount *big.Int, token string) error {
 	parts := strings.Split(token, "_")
 	if len(parts) > 1 {
@@ -107,6 +122,10 @@ func balanceList(stub shim.ChaincodeStubInterface, tokenType StateKey, address *
 		if err != nil {
 			return nil, err
 		}
+		if len(keyParts) == 1 {
+			// balance of the base token is kept next to industrial groups
+			continue
+		}
 		if len(keyParts) < 2 { //nolint:gomnd
 			return nil, fmt.Errorf("incorrect composite key %s (two-part key expected)", kv.Key)
 		}
@@ -120,6 +139,9 @@ func (bc *BaseContract) IndustrialBalanceGet(address *types.Address) (map[string
 }
 
 func (bc *BaseContract) IndustrialBalanceTransfer(token string, from *types.Address, to *types.Address, amount *big.Int, reason string) error {
+	if err := bc.freezeCheck(from, to); err != nil {
+		return err
+	}
 	parts := strings.Split(token, "_")
 	token = parts[len(parts)-1]
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
@@ -129,6 +151,9 @@ func (bc *BaseContract) IndustrialBalanceTransfer(token string, from *types.Addr
 }
 
 func (bc *BaseContract) IndustrialBalanceAdd(token string, address *types.Address, amount *big.Int, reason string) error {
+	if err := bc.freezeCheck(nil, address); err != nil {
+		return err
+	}
 	parts := strings.Split(token, "_")
 	token = parts[len(parts)-1]
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
@@ -138,6 +163,9 @@ func (bc *BaseContract) IndustrialBalanceAdd(token string, address *types.Addres
 }
 
 func (bc *BaseContract) IndustrialBalanceSub(token string, address *types.Address, amount *big.Int, reason string) error {
+	if err := bc.freezeCheck(address, nil); err != nil {
+		return err
+	}
 	parts := strings.Split(token, "_")
 	token = parts[len(parts)-1]
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
@@ -147,6 +175,12 @@ func (bc *BaseContract) IndustrialBalanceSub(token string, address *types.Addres
 }
 
 func (bc *BaseContract) TokenBalanceTransfer(from *types.Address, to *types.Address, amount *big.Int, reason string) error {
+	if err := bc.freezeCheck(from, to); err != nil {
+		return err
+	}
+	if err := bc.checkTransferRules(bc.id, from, to, amount); err != nil {
+		return err
+	}
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
 		stub.AddAccountingRecord(bc.id, from, to, amount, reason)
 	}
@@ -154,6 +188,9 @@ func (bc *BaseContract) TokenBalanceTransfer(from *types.Address, to *types.Addr
 }
 
 func (bc *BaseContract) AllowedBalanceTransfer(token string, from *types.Address, to *types.Address, amount *big.Int, reason string) error {
+	if err := bc.checkTransferRules(token, from, to, amount); err != nil {
+		return err
+	}
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
 		stub.AddAccountingRecord(token, from, to, amount, reason)
 	}
@@ -166,6 +203,12 @@ func (bc *BaseContract) TokenBalanceGet(address *types.Address) (*big.Int, error
 }
 
 func (bc *BaseContract) TokenBalanceAdd(address *types.Address, amount *big.Int, reason string) error {
+	if err := bc.freezeCheck(nil, address); err != nil {
+		return err
+	}
+	if err := bc.checkTransferRules(bc.id, nil, address, amount); err != nil {
+		return err
+	}
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
 		stub.AddAccountingRecord(bc.id, &types.Address{}, address, amount, reason)
 	}
@@ -173,6 +216,9 @@ func (bc *BaseContract) TokenBalanceAdd(address *types.Address, amount *big.Int,
 }
 
 func (bc *BaseContract) TokenBalanceSub(address *types.Address, amount *big.Int, reason string) error {
+	if err := bc.freezeCheck(address, nil); err != nil {
+		return err
+	}
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
 		stub.AddAccountingRecord(bc.id, address, &types.Address{}, amount, reason)
 	}
@@ -185,6 +231,9 @@ func (bc *BaseContract) TokenBalanceGetLocked(address *types.Address) (*big.Int,
 }
 
 func (bc *BaseContract) TokenBalanceLock(address *types.Address, amount *big.Int) error {
+	if err := bc.freezeCheck(address, nil); err != nil {
+		return err
+	}
 	if err := balanceSub(bc.stub, StateKeyTokenBalance, address, amount); err != nil {
 		return err
 	}
@@ -199,6 +248,12 @@ func (bc *BaseContract) TokenBalanceUnlock(address *types.Address, amount *big.I
 }
 
 func (bc *BaseContract) TokenBalanceTransferLocked(from *types.Address, to *types.Address, amount *big.Int, reason string) error {
+	if err := bc.freezeCheck(from, to); err != nil {
+		return err
+	}
+	if err := bc.checkTransferRules(bc.id, from, to, amount); err != nil {
+		return err
+	}
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
 		stub.AddAccountingRecord(bc.id, from, to, amount, reason)
 	}
@@ -215,12 +270,52 @@ func (bc *BaseContract) TokenBalanceBurnLocked(address *types.Address, amount *b
 	return balanceSub(bc.stub, StateKeyLockedTokenBalance, address, amount)
 }
 
+// TokenBalanceGetVesting returns the amount vested to the address which isn't claimed yet
+func (bc *BaseContract) TokenBalanceGetVesting(address *types.Address) (*big.Int, error) {
+	_, balance, err := balanceGet(bc.stub, StateKeyVestingBalance, address)
+	return balance, err
+}
+
+// TokenBalanceTransferVesting transfers the amount to the vesting balance of the recipient,
+// which is kept apart from the locked balance till it's claimed
+func (bc *BaseContract) TokenBalanceTransferVesting(from *types.Address, to *types.Address, amount *big.Int, reason string) error {
+	if err := bc.freezeCheck(from, to); err != nil {
+		return err
+	}
+	if err := bc.checkTransferRules(bc.id, from, to, amount); err != nil {
+		return err
+	}
+	if stub, ok := bc.GetStub().(*batchTxStub); ok {
+		stub.AddAccountingRecord(bc.id, from, to, amount, reason)
+	}
+	if err := balanceSub(bc.stub, StateKeyTokenBalance, from, amount); err != nil {
+		return err
+	}
+	return balanceAdd(bc.stub, StateKeyVestingBalance, to, amount)
+}
+
+// TokenBalanceUnlockVesting moves the claimed amount from the vesting balance to the token balance
+func (bc *BaseContract) TokenBalanceUnlockVesting(address *types.Address, amount *big.Int) error {
+	if err := balanceSub(bc.stub, StateKeyVestingBalance, address, amount); err != nil {
+		return err
+	}
+	return balanceAdd(bc.stub, StateKeyTokenBalance, address, amount)
+}
+
 func (bc *BaseContract) AllowedBalanceGet(token string, address *types.Address) (*big.Int, error) {
 	_, balance, err := balanceGet(bc.stub, StateKeyAllowedBalance, address, token)
 	return balance, err
 }
 
 func (bc *BaseContract) AllowedBalanceAdd(token string, address *types.Address, amount *big.Int, reason string) error {
+	if err := bc.checkTransferRules(token, nil, address, amount); err != nil {
+		return err
+	}
+	return bc.allowedBalanceAdd(token, address, amount, reason)
+}
+
+// allowedBalanceAdd adds the allowed balance without transfer rules checks, it's used to refund swaps
+func (bc *BaseContract) allowedBalanceAdd(token string, address *types.Address, amount *big.Int, reason string) error {
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
 		stub.AddAccountingRecord(token, &types.Address{}, address, amount, reason)
 	}
@@ -249,6 +344,11 @@ func (bc *BaseContract) AllowedIndustrialBalanceTransfer(from *types.Address, to
 }
 
 func (bc *BaseContract) AllowedIndustrialBalanceAdd(address *types.Address, industrialAssets []*pb.Asset, reason string) error {
+	return bc.allowedIndustrialBalanceAdd(address, industrialAssets, reason)
+}
+
+// allowedIndustrialBalanceAdd adds allowed balances, it's used to refund multiswaps
+func (bc *BaseContract) allowedIndustrialBalanceAdd(address *types.Address, industrialAssets []*pb.Asset, reason string) error {
 	for _, industrialAsset := range industrialAssets {
 		amount := new(big.Int).SetBytes(industrialAsset.Amount)
 		if stub, ok := bc.GetStub().(*batchTxStub); ok {
@@ -291,6 +391,9 @@ func (bc *BaseContract) AllowedBalanceUnLock(token string, address *types.Addres
 }
 
 func (bc *BaseContract) AllowedBalanceTransferLocked(token string, from *types.Address, to *types.Address, amount *big.Int, reason string) error {
+	if err := bc.checkTransferRules(token, from, to, amount); err != nil {
+		return err
+	}
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
 		stub.AddAccountingRecord(token, from, to, amount, reason)
 	}
@@ -312,6 +415,9 @@ func (bc *BaseContract) IndustrialBalanceGetLocked(address *types.Address) (map[
 }
 
 func (bc *BaseContract) IndustrialBalanceLock(token string, address *types.Address, amount *big.Int) error {
+	if err := bc.freezeCheck(address, nil); err != nil {
+		return err
+	}
 	parts := strings.Split(token, "_")
 	token = parts[len(parts)-1]
 	if err := balanceSub(bc.stub, StateKeyTokenBalance, address, amount, token); err != nil {
@@ -330,6 +436,9 @@ func (bc *BaseContract) IndustrialBalanceUnLock(token string, address *types.Add
 }
 
 func (bc *BaseContract) IndustrialBalanceTransferLocked(token string, from *types.Address, to *types.Address, amount *big.Int, reason string) error {
+	if err := bc.freezeCheck(from, to); err != nil {
+		return err
+	}
 	parts := strings.Split(token, "_")
 	token = parts[len(parts)-1]
 	if stub, ok := bc.GetStub().(*batchTxStub); ok {
@@ -355,6 +464,44 @@ func (bc *BaseContract) AllowedBalanceGetAll(addr *types.Address) (map[string]st
 	return balanceList(bc.stub, StateKeyAllowedBalance, addr)
 }
 
+// AllowanceGet returns the amount which the spender can transfer from the owner
+func (bc *BaseContract) AllowanceGet(owner *types.Address, spender *types.Address) (*big.Int, error) {
+	_, allowance, err := balanceGet(bc.stub, StateKeyAllowance, owner, spender.String())
+	return allowance, err
+}
+
+// AllowanceSet sets the amount which the spender can transfer from the owner
+func (bc *BaseContract) AllowanceSet(owner *types.Address, spender *types.Address, amount *big.Int) error {
+	if amount.Cmp(big.NewInt(0)) < 0 {
+		return errors.New("amount should be positive")
+	}
+	key, _, err := balanceGet(bc.stub, StateKeyAllowance, owner, spender.String())
+	if err != nil {
+		return err
+	}
+	if amount.Cmp(big.NewInt(0)) == 0 {
+		return bc.stub.DelState(key)
+	}
+	return bc.stub.PutState(key, amount.Bytes())
+}
+
+// AllowanceAdd increases the amount which the spender can transfer from the owner
+func (bc *BaseContract) AllowanceAdd(owner *types.Address, spender *types.Address, amount *big.Int) error {
+	return balanceAdd(bc.stub, StateKeyAllowance, owner, amount, spender.String())
+}
+
+// AllowanceSub decreases the amount which the spender can transfer from the owner
+func (bc *BaseContract) AllowanceSub(owner *types.Address, spender *types.Address, amount *big.Int) error {
+	allowance, err := bc.AllowanceGet(owner, spender)
+	if err != nil {
+		return err
+	}
+	if allowance.Cmp(amount) < 0 {
+		return errors.New("insufficient allowance")
+	}
+	return bc.AllowanceSet(owner, spender, new(big.Int).Sub(allowance, amount))
+}
+
 func givenBalanceGet(stub shim.ChaincodeStubInterface, contract string) (string, *big.Int, error) {
 	prefix := hex.EncodeToString([]byte{byte(StateKeyGivenBalance)})
 	key, err := stub.CreateCompositeKey(prefix, []string{contract})
@@ -386,3 +533,153 @@ func GivenBalanceSub(stub shim.ChaincodeStubInterface, contract string, amount *
 	}
 	return stub.PutState(key, new(big.Int).Sub(balance, amount).Bytes())
 }
+
+func givenBalanceLimitKey(stub shim.ChaincodeStubInterface, contract string) (string, error) {
+	prefix := hex.EncodeToString([]byte{byte(StateKeyGivenBalanceLimit)})
+	return stub.CreateCompositeKey(prefix, []string{contract})
+}
+
+// GivenBalanceLimitGet returns the limit of the given balance to the contract, zero means no limit
+func GivenBalanceLimitGet(stub shim.ChaincodeStubInterface, contract string) (*big.Int, error) {
+	key, err := givenBalanceLimitKey(stub, contract)
+	if err != nil {
+		return nil, err
+	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(data), nil
+}
+
+// GivenBalanceLimitSet sets the limit of the given balance to the contract, zero limit removes it
+func GivenBalanceLimitSet(stub shim.ChaincodeStubInterface, contract string, limit *big.Int) error {
+	if limit.Sign() < 0 {
+		return errors.New("limit should be non-negative")
+	}
+	key, err := givenBalanceLimitKey(stub, contract)
+	if err != nil {
+		return err
+	}
+	if limit.Sign() == 0 {
+		return stub.DelState(key)
+	}
+	return stub.PutState(key, limit.Bytes())
+}
+
+// givenBalanceReserve reserves the amount of the token of the contract sent to the counterpart
+// by the swap which isn't done yet. The given balance together with all reservations
+// has to stay within the limit, so open swaps can't exceed it together.
+func givenBalanceReserve(stub shim.ChaincodeStubInterface, contract string, amount *big.Int) error {
+	limit, err := GivenBalanceLimitGet(stub, contract)
+	if err != nil {
+		return err
+	}
+	if limit.Sign() != 0 {
+		_, balance, err := givenBalanceGet(stub, contract)
+		if err != nil {
+			return err
+		}
+		_, reserved, err := givenBalanceReservedGet(stub, contract)
+		if err != nil {
+			return err
+		}
+		total := new(big.Int).Add(balance, reserved)
+		if total.Add(total, amount).Cmp(limit) > 0 {
+			return fmt.Errorf("given balance limit to %s is exceeded", contract)
+		}
+	}
+	return givenBalanceReservedAdd(stub, contract, amount)
+}
+
+func givenBalanceReservedGet(stub shim.ChaincodeStubInterface, contract string) (string, *big.Int, error) {
+	prefix := hex.EncodeToString([]byte{byte(StateKeyGivenBalanceReserved)})
+	key, err := stub.CreateCompositeKey(prefix, []string{contract})
+	if err != nil {
+		return key, nil, err
+	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return key, nil, err
+	}
+	return key, new(big.Int).SetBytes(data), nil
+}
+
+// givenBalanceReservedAdd reserves the amount without the limit check, it's used when the value
+// returns from the counterpart, so the returned value is reserved till the swap is done or refunded
+func givenBalanceReservedAdd(stub shim.ChaincodeStubInterface, contract string, amount *big.Int) error {
+	key, reserved, err := givenBalanceReservedGet(stub, contract)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, new(big.Int).Add(reserved, amount).Bytes())
+}
+
+// givenBalanceReservedSub releases the reservation of the swap which is done or refunded.
+// Swaps begun before reservations were kept have nothing reserved, so the reservation stays non-negative.
+func givenBalanceReservedSub(stub shim.ChaincodeStubInterface, contract string, amount *big.Int) error {
+	key, reserved, err := givenBalanceReservedGet(stub, contract)
+	if err != nil {
+		return err
+	}
+	if reserved.Cmp(amount) <= 0 {
+		return stub.DelState(key)
+	}
+	return stub.PutState(key, new(big.Int).Sub(reserved, amount).Bytes())
+}
+
+// GivenBalance is the value sent to the counterpart contract, the value reserved by its open swaps
+// and the limit of both, zero limit means no limit
+type GivenBalance struct {
+	Contract string   `json:"contract"`
+	Balance  *big.Int `json:"balance"`
+	Reserved *big.Int `json:"reserved"`
+	Limit    *big.Int `json:"limit"`
+}
+
+// QueryGivenBalances returns given balances, reservations and limits for all counterpart contracts
+func (bc *BaseContract) QueryGivenBalances() ([]GivenBalance, error) {
+	res := make(map[string]*GivenBalance)
+	for _, stateKey := range []StateKey{StateKeyGivenBalance, StateKeyGivenBalanceReserved, StateKeyGivenBalanceLimit} {
+		prefix := hex.EncodeToString([]byte{byte(stateKey)})
+		iter, err := bc.stub.GetStateByPartialCompositeKey(prefix, []string{})
+		if err != nil {
+			return nil, err
+		}
+		for iter.HasNext() {
+			kv, err := iter.Next()
+			if err != nil {
+				_ = iter.Close()
+				return nil, err
+			}
+			_, keyParts, err := bc.stub.SplitCompositeKey(kv.Key)
+			if err != nil || len(keyParts) == 0 {
+				_ = iter.Close()
+				return nil, fmt.Errorf("incorrect composite key %s", kv.Key)
+			}
+			gb, ok := res[keyParts[0]]
+			if !ok {
+				gb = &GivenBalance{Contract: keyParts[0], Balance: new(big.Int), Reserved: new(big.Int), Limit: new(big.Int)}
+				res[keyParts[0]] = gb
+			}
+			switch stateKey {
+			case StateKeyGivenBalance:
+				gb.Balance.SetBytes(kv.Value)
+			case StateKeyGivenBalanceReserved:
+				gb.Reserved.SetBytes(kv.Value)
+			default:
+				gb.Limit.SetBytes(kv.Value)
+			}
+		}
+		_ = iter.Close()
+	}
+
+	out := make([]GivenBalance, 0, len(res))
+	for _, gb := range res {
+		out = append(out, *gb)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Contract < out[j].Contract
+	})
+	return out, nil
+}
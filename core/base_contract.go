@@ -2,7 +2,6 @@ package core
 
 import (
 	"encoding/hex"
-	"log"
 	"sort"
 	"strconv"
 
@@ -21,10 +20,17 @@ type BaseContract struct {
 	atomyzeSKI   []byte
 	initArgs     []string
 	noncePrefix  StateKey
+	logger       ContractLogger
+	swapConfig   swapConfig
+
+	swapFeeCalculator SwapFeeCalculator
+	transferRules     TransferRules
 }
 
 func (bc *BaseContract) baseContractInit(cc BaseContractInterface) {
 	bc.id = cc.GetID()
+	bc.swapFeeCalculator, _ = cc.(SwapFeeCalculator)
+	bc.transferRules, _ = cc.(TransferRules)
 }
 
 func (bc *BaseContract) GetStub() shim.ChaincodeStubInterface {
@@ -36,10 +42,33 @@ func (bc *BaseContract) GetCreatorSKI() string {
 	if ok {
 		return stub.creatorSKI
 	}
-	log.Println("Couldn't get creatorSKI because stub is not batchTxStub")
+	bc.GetLogger().Warningf("Couldn't get creatorSKI because stub is not batchTxStub")
 	return ""
 }
 
+// GetLogger returns logger with the context of the current transaction
+func (bc *BaseContract) GetLogger() ContractLogger {
+	if stub, ok := bc.stub.(*batchTxStub); ok {
+		return stub.log
+	}
+	logger := bc.logger
+	if logger == nil {
+		logger = NewDefaultLogger()
+	}
+	if bc.stub == nil {
+		return logger
+	}
+	return logger.With(LogFields{LogFieldChannel: bc.stub.GetChannelID(), LogFieldTxID: bc.stub.GetTxID()})
+}
+
+func (bc *BaseContract) setLogger(logger ContractLogger) {
+	bc.logger = logger
+}
+
+func (bc *BaseContract) setSwapConfig(config swapConfig) {
+	bc.swapConfig = config
+}
+
 func (bc *BaseContract) GetMethods() []string {
 	return bc.methods
 }
@@ -111,6 +140,13 @@ type BaseContractInterface interface { //nolint:interfacebloat
 	setStubAndInitArgs(shim.ChaincodeStubInterface, string, []byte, []string, StateKey)
 	GetID() string
 	baseContractInit(BaseContractInterface)
+	setLogger(ContractLogger)
+	setSwapConfig(swapConfig)
+	holdRelease(*pb.Hold) error
+	freezeCheck(from *types.Address, to *types.Address) error
+	checkTransferRules(token string, from *types.Address, to *types.Address, amount *big.Int) error
+	allowedBalanceAdd(token string, address *types.Address, amount *big.Int, reason string) error
+	allowedIndustrialBalanceAdd(address *types.Address, industrialAssets []*pb.Asset, reason string) error
 
 	TokenBalanceTransfer(from *types.Address, to *types.Address, amount *big.Int, reason string) error
 	AllowedBalanceTransfer(token string, from *types.Address, to *types.Address, amount *big.Int, reason string) error
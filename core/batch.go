@@ -25,9 +25,21 @@ func (cc *ChainCode) saveToBatch(
 	sender *proto.Address,
 	args []string,
 	nonce uint64,
+	deadline int64,
 ) error {
-	logger := Logger()
+	return cc.putToBatch(stub, &proto.PendingTx{
+		Method:     fn,
+		Sender:     sender,
+		Args:       args,
+		CreatorSKI: creatorSKI,
+		Nonce:      nonce,
+		Deadline:   deadline,
+	})
+}
+
+func (cc *ChainCode) putToBatch(stub shim.ChaincodeStubInterface, pending *proto.PendingTx) error {
 	txID := stub.GetTxID()
+	logger := cc.log.With(LogFields{LogFieldTxID: txID, LogFieldMethod: pending.Method})
 	key, err := stub.CreateCompositeKey(cc.batchPrefix, []string{txID})
 	if err != nil {
 		logger.Errorf("Couldn't create composite key for tx %s: %s", txID, err.Error())
@@ -39,15 +51,9 @@ func (cc *ChainCode) saveToBatch(
 		logger.Errorf("Couldn't get timestamp for tx %s: %s", txID, err.Error())
 		return err
 	}
+	pending.Timestamp = txTimestamp.Seconds
 
-	data, err := pb.Marshal(&proto.PendingTx{
-		Method:     fn,
-		Sender:     sender,
-		Args:       args,
-		CreatorSKI: creatorSKI,
-		Timestamp:  txTimestamp.Seconds,
-		Nonce:      nonce,
-	})
+	data, err := pb.Marshal(pending)
 	if err != nil {
 		logger.Errorf("Couldn't marshal transaction %s: %s", txID, err.Error())
 		return err
@@ -60,7 +66,7 @@ func (cc *ChainCode) loadFromBatch( //nolint:funlen
 	txID string,
 	batchTimestamp int64,
 ) (*proto.PendingTx, error) {
-	logger := Logger()
+	logger := cc.log.With(LogFields{LogFieldTxID: txID})
 	key, err := stub.CreateCompositeKey(cc.batchPrefix, []string{txID})
 	if err != nil {
 		logger.Errorf("Couldn't create composite key for tx %s: %s", txID, err.Error())
@@ -104,7 +110,11 @@ func (cc *ChainCode) loadFromBatch( //nolint:funlen
 		}
 	}
 
-	if cc.txTTL > 0 && batchTimestamp-pending.Timestamp > int64(cc.txTTL) {
+	txTTL := cc.txTTL
+	if methodTxTTL, ok := cc.methodTxTTL[pending.Method]; ok {
+		txTTL = methodTxTTL
+	}
+	if txTTL > 0 && batchTimestamp-pending.Timestamp > int64(txTTL) {
 		logger.Errorf("Transaction ttl expired %s", txID)
 		return pending, errors.New("transaction expired")
 	}
@@ -128,6 +138,12 @@ func (cc *ChainCode) loadFromBatch( //nolint:funlen
 			logger.Errorf("incorrect tx %s nonce: %s", txID, err.Error())
 			return pending, err
 		}
+		if pending.Relay != nil {
+			if err = cc.nonceCheckFn(stub, types.NewSenderFromAddr((*types.Address)(pending.Relay.Relayer)), pending.Relay.Nonce); err != nil {
+				logger.Errorf("incorrect tx %s relayer nonce: %s", txID, err.Error())
+				return pending, err
+			}
+		}
 	}
 
 	return pending, nil
@@ -135,12 +151,14 @@ func (cc *ChainCode) loadFromBatch( //nolint:funlen
 
 //nolint:funlen
 func (cc *ChainCode) batchExecute(stub shim.ChaincodeStubInterface, creatorSKI string, dataIn string) peer.Response {
-	logger := Logger()
 	batchID := stub.GetTxID()
+	logger := cc.log.With(LogFields{LogFieldChannel: stub.GetChannelID(), LogFieldBatchID: batchID})
 	btchStub := newBatchStub(stub)
+	btchStub.log = logger
 	start := time.Now()
 	defer func() {
-		logger.Infof("batch %s elapsed time %d ms", batchID, time.Since(start).Milliseconds())
+		logger.With(LogFields{LogFieldElapsedMs: time.Since(start).Milliseconds()}).
+			Infof("batch %s elapsed time", batchID)
 	}()
 	response := proto.BatchResponse{}
 	events := proto.BatchEvent{}
@@ -162,22 +180,44 @@ func (cc *ChainCode) batchExecute(stub shim.ChaincodeStubInterface, creatorSKI s
 		events.Events = append(events.Events, event)
 	}
 
+	addSwapEvent := func(method string, resp *proto.SwapResponse) *proto.SwapResponse {
+		if event := swapBatchEvent(method, resp); event != nil {
+			events.Events = append(events.Events, event)
+		}
+		return resp
+	}
+
 	if !cc.disableSwaps {
 		for _, swap := range batch.Swaps {
-			response.SwapResponses = append(response.SwapResponses, swapAnswer(btchStub, creatorSKI, swap))
+			resp := swapAnswer(btchStub, creatorSKI, swap, cc.swapConfig.robotSide())
+			response.SwapResponses = append(response.SwapResponses, addSwapEvent("swapAnswer", resp))
 		}
 		for _, swapKey := range batch.Keys {
-			response.SwapKeyResponses = append(response.SwapKeyResponses, swapRobotDone(btchStub, creatorSKI, swapKey.Id, swapKey.Key))
+			resp := swapRobotDone(btchStub, creatorSKI, swapKey.Id, swapKey.Key)
+			response.SwapKeyResponses = append(response.SwapKeyResponses, addSwapEvent("swapRobotDone", resp))
 		}
 	}
 
 	if !cc.disableMultiSwaps {
 		for _, swap := range batch.MultiSwaps {
-			response.SwapResponses = append(response.SwapResponses, multiSwapAnswer(btchStub, creatorSKI, swap))
+			resp := multiSwapAnswer(btchStub, creatorSKI, swap, cc.swapConfig.robotSide())
+			response.SwapResponses = append(response.SwapResponses, addSwapEvent("multiSwapAnswer", resp))
 		}
 		for _, swapKey := range batch.MultiSwapsKeys {
-			response.SwapKeyResponses = append(response.SwapKeyResponses, multiSwapRobotDone(btchStub, creatorSKI, swapKey.Id, swapKey.Key))
+			resp := multiSwapRobotDone(btchStub, creatorSKI, swapKey.Id, swapKey.Key)
+			response.SwapKeyResponses = append(response.SwapKeyResponses, addSwapEvent("multiSwapRobotDone", resp))
+		}
+	}
+
+	if batch.SweepExpiredSwaps > 0 {
+		limit := int(batch.SweepExpiredSwaps)
+		if limit > maxSweepSwaps {
+			limit = maxSweepSwaps
 		}
+		swept, holds, event := cc.sweepExpiredSwaps(btchStub, creatorSKI, limit, batchTimestamp.Seconds)
+		response.SweptSwapResponses = swept
+		response.SweptHoldResponses = holds
+		events.Events = append(events.Events, event)
 	}
 
 	if err := btchStub.Commit(); err != nil {
@@ -214,13 +254,14 @@ type TxResponse struct {
 }
 
 func (cc *ChainCode) batchedTxExecute(stub *batchStub, binaryTxID []byte, batchTimestamp int64) (r *proto.TxResponse, e *proto.BatchTxEvent) {
-	logger := Logger()
 	start := time.Now()
 	methodName := "unknown"
 
 	txID := hex.EncodeToString(binaryTxID)
+	logger := stub.log.With(LogFields{LogFieldTxID: txID})
 	defer func() {
-		logger.Infof("batched method %s txid %s elapsed time %d ms", methodName, txID, time.Since(start).Milliseconds())
+		logger.With(LogFields{LogFieldElapsedMs: time.Since(start).Milliseconds()}).
+			Infof("batched method %s txid %s elapsed time", methodName, txID)
 	}()
 
 	r = &proto.TxResponse{Id: binaryTxID, Error: &proto.ResponseError{Error: "panic batchedTxExecute"}}
@@ -232,6 +273,14 @@ func (cc *ChainCode) batchedTxExecute(stub *batchStub, binaryTxID []byte, batchT
 	}()
 
 	pending, err := cc.loadFromBatch(stub.ChaincodeStubInterface, txID, batchTimestamp)
+	if pending != nil {
+		methodName = pending.Method
+		fields := LogFields{LogFieldMethod: pending.Method}
+		if pending.Sender != nil {
+			fields[LogFieldSender] = (*types.Address)(pending.Sender).String()
+		}
+		logger = logger.With(fields)
+	}
 	if err != nil && pending != nil {
 		ee := proto.ResponseError{Error: fmt.Sprintf("function and args loading error: %s", err.Error())}
 		return &proto.TxResponse{Id: binaryTxID, Method: pending.Method, Error: &ee}, &proto.BatchTxEvent{Id: binaryTxID, Method: pending.Method, Error: &ee}
@@ -240,7 +289,15 @@ func (cc *ChainCode) batchedTxExecute(stub *batchStub, binaryTxID []byte, batchT
 		return &proto.TxResponse{Id: binaryTxID, Error: &ee}, &proto.BatchTxEvent{Id: binaryTxID, Error: &ee}
 	}
 
+	if pending.Deadline != 0 && batchTimestamp > pending.Deadline {
+		logger.Errorf("Transaction %s deadline %d expired", txID, pending.Deadline)
+		ee := proto.ResponseError{Error: ErrDeadlineExpired}
+		return &proto.TxResponse{Id: binaryTxID, Method: pending.Method, Error: &ee}, &proto.BatchTxEvent{Id: binaryTxID, Method: pending.Method, Error: &ee}
+	}
+
 	txStub := stub.newTxStub(txID, hex.EncodeToString(pending.CreatorSKI))
+	txStub.relay = pending.Relay
+	txStub.log = logger
 	method, exists := cc.methods[pending.Method]
 	if !exists {
 		logger.Infof("Unknown method %s in tx %s", pending.Method, txID)
@@ -248,7 +305,12 @@ func (cc *ChainCode) batchedTxExecute(stub *batchStub, binaryTxID []byte, batchT
 		return &proto.TxResponse{Id: binaryTxID, Method: pending.Method, Error: &ee}, &proto.BatchTxEvent{Id: binaryTxID, Method: pending.Method, Error: &ee}
 	}
 
-	response, err := cc.callMethod(txStub, method, pending.Sender, pending.Args)
+	var response []byte
+	if cc.isBuiltinMulticall(pending.Method) {
+		response, err = cc.multicallExecute(txStub, pending.Sender, pending.Args)
+	} else {
+		response, err = cc.callMethod(txStub, method, pending.Sender, pending.Args)
+	}
 	if err != nil {
 		ee := proto.ResponseError{Error: err.Error()}
 		return &proto.TxResponse{Id: binaryTxID, Method: pending.Method, Error: &ee}, &proto.BatchTxEvent{Id: binaryTxID, Method: pending.Method, Error: &ee}
@@ -269,13 +331,14 @@ func (cc *ChainCode) batchedTxExecute(stub *batchStub, binaryTxID []byte, batchT
 			Id:         binaryTxID,
 			Method:     pending.Method,
 			Accounting: txStub.accounting,
+			Refs:       txStub.refs,
 			Events:     events,
 			Result:     response,
 		}
 }
 
-func batchedTxDelete(stub shim.ChaincodeStubInterface, prefix string, txID string) {
-	logger := Logger()
+func (cc *ChainCode) batchedTxDelete(stub shim.ChaincodeStubInterface, prefix string, txID string) {
+	logger := cc.log.With(LogFields{LogFieldTxID: txID})
 	key, err := stub.CreateCompositeKey(prefix, []string{txID})
 	if err != nil {
 		logger.Errorf("Couldn't create batch key for tx %s: %s", txID, err.Error())
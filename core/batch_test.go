@@ -115,7 +115,7 @@ func SaveAndLoadToBatchTest(t *testing.T, ser *serieBatches) {
 	batchTimestamp, err := mockStub.GetTxTimestamp()
 	assert.NoError(t, err)
 
-	errSave := chainCode.saveToBatch(mockStub, ser.FnName, creatorSKI, sender, testArgs, uint64(batchTimestamp.Seconds))
+	errSave := chainCode.saveToBatch(mockStub, ser.FnName, creatorSKI, sender, testArgs, uint64(batchTimestamp.Seconds), 0)
 	assert.NoError(t, errSave)
 	mockStub.MockTransactionEnd(testEncodedTxID)
 	state, err := mockStub.GetState(fmt.Sprintf("\u0000batchTransactions\u0000%s\u0000", testEncodedTxID))
@@ -186,7 +186,7 @@ func BatchExecuteTest(t *testing.T, ser *serieBatcheExecute) {
 	batchTimestamp, err := mockStub.GetTxTimestamp()
 	assert.NoError(t, err)
 
-	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds))
+	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds), 0)
 	assert.NoError(t, err)
 	mockStub.MockTransactionEnd(testEncodedTxID)
 	state, err := mockStub.GetState(fmt.Sprintf("\u0000batchTransactions\u0000%s\u0000", testEncodedTxID))
@@ -236,7 +236,7 @@ func TestBatchedTxExecute(t *testing.T) {
 	batchTimestamp, err := mockStub.GetTxTimestamp()
 	assert.NoError(t, err)
 
-	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds))
+	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds), 0)
 	assert.NoError(t, err)
 	mockStub.MockTransactionEnd(testEncodedTxID)
 
@@ -260,7 +260,7 @@ func TestBatchedTxDelete(t *testing.T) {
 	batchTimestamp, err := mockStub.GetTxTimestamp()
 	assert.NoError(t, err)
 
-	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds))
+	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds), 0)
 	assert.NoError(t, err)
 	mockStub.MockTransactionEnd(testEncodedTxID)
 
@@ -277,7 +277,7 @@ func TestBatchedTxDelete(t *testing.T) {
 	assert.Equal(t, pending.Timestamp, batchTimestamp.Seconds)
 	assert.Equal(t, pending.Args, args)
 
-	batchedTxDelete(mockStub, batchKey, testEncodedTxID)
+	chainCode.batchedTxDelete(mockStub, batchKey, testEncodedTxID)
 
 	stateAfterDel, err := mockStub.GetState(fmt.Sprintf("\u0000batchTransactions\u0000%s\u0000", testEncodedTxID))
 	assert.Nil(t, stateAfterDel)
@@ -300,7 +300,7 @@ func TestOkTxExecuteWithTTL(t *testing.T) {
 	batchTimestamp, err := mockStub.GetTxTimestamp()
 	assert.NoError(t, err)
 
-	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds))
+	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds), 0)
 	assert.NoError(t, err)
 	mockStub.MockTransactionEnd(testEncodedTxID)
 
@@ -332,7 +332,7 @@ func TestFailTxExecuteWithTTL(t *testing.T) {
 	batchTimestamp, err := mockStub.GetTxTimestamp()
 	assert.NoError(t, err)
 
-	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds))
+	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds), 0)
 	assert.NoError(t, err)
 	mockStub.MockTransactionEnd(testEncodedTxID)
 
@@ -346,3 +346,64 @@ func TestFailTxExecuteWithTTL(t *testing.T) {
 	assert.Equal(t, resp.Error.Error, "function and args loading error: transaction expired")
 	assert.Equal(t, event.Error.Error, "function and args loading error: transaction expired")
 }
+
+// TestFailTxExecuteWithDeadline - negative test for batchedTxExecute with expired deadline
+func TestFailTxExecuteWithDeadline(t *testing.T) {
+	chainCode, err := NewChainCode(&testContract{}, allowedMspID, nil)
+	assert.NoError(t, err)
+
+	chainCode.init = &proto.InitArgs{}
+	mockStub := stub.NewMockStub(testChaincodeName, chainCode)
+	mockStub.TxID = testEncodedTxID
+	btchStub := newBatchStub(mockStub)
+	mockStub.MockTransactionStart(testEncodedTxID)
+
+	batchTimestamp, err := mockStub.GetTxTimestamp()
+	assert.NoError(t, err)
+
+	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds), batchTimestamp.Seconds+5)
+	assert.NoError(t, err)
+	mockStub.MockTransactionEnd(testEncodedTxID)
+
+	resp, event := chainCode.batchedTxExecute(btchStub, txIDBytes, batchTimestamp.Seconds+6)
+	assert.NotNil(t, resp.Error)
+	assert.NotNil(t, event.Error)
+	assert.Equal(t, resp.Method, testFunctionBatch)
+	assert.Equal(t, resp.Error.Error, ErrDeadlineExpired)
+	assert.Equal(t, event.Error.Error, ErrDeadlineExpired)
+}
+
+// TestTxExecuteWithMethodTTL - per-method ttl overrides the contract ttl
+func TestTxExecuteWithMethodTTL(t *testing.T) {
+	chainCode, err := NewChainCode(&testContract{}, allowedMspID, &ContractOptions{
+		TxTTL:       5,
+		MethodTxTTL: map[string]uint{testFunctionBatch: 60},
+	})
+	assert.NoError(t, err)
+
+	chainCode.init = &proto.InitArgs{}
+	mockStub := stub.NewMockStub(testChaincodeName, chainCode)
+	mockStub.TxID = testEncodedTxID
+	btchStub := newBatchStub(mockStub)
+	mockStub.MockTransactionStart(testEncodedTxID)
+
+	batchTimestamp, err := mockStub.GetTxTimestamp()
+	assert.NoError(t, err)
+
+	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds), 0)
+	assert.NoError(t, err)
+	mockStub.MockTransactionEnd(testEncodedTxID)
+
+	resp, event := chainCode.batchedTxExecute(btchStub, txIDBytes, batchTimestamp.Seconds+30)
+	assert.Nil(t, resp.Error)
+	assert.Nil(t, event.Error)
+
+	mockStub.MockTransactionStart(testEncodedTxID)
+	err = chainCode.saveToBatch(mockStub, testFunctionBatch, creatorSKI, nil, args, uint64(batchTimestamp.Seconds), 0)
+	assert.NoError(t, err)
+	mockStub.MockTransactionEnd(testEncodedTxID)
+
+	resp, _ = chainCode.batchedTxExecute(btchStub, txIDBytes, batchTimestamp.Seconds+61)
+	assert.NotNil(t, resp.Error)
+	assert.Equal(t, resp.Error.Error, "function and args loading error: transaction expired")
+}
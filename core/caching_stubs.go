@@ -14,12 +14,14 @@ type batchStub struct {
 	batchCache map[string]*proto.WriteElement
 	swaps      []*proto.Swap
 	multiSwaps []*proto.MultiSwap
+	log        ContractLogger
 }
 
 func newBatchStub(stub shim.ChaincodeStubInterface) *batchStub {
 	return &batchStub{
 		ChaincodeStubInterface: stub,
 		batchCache:             make(map[string]*proto.WriteElement),
+		log:                    NewDefaultLogger(),
 	}
 }
 
@@ -67,6 +69,9 @@ type batchTxStub struct {
 	txCache    map[string]*proto.WriteElement
 	events     map[string][]byte
 	accounting []*proto.AccountingRecord
+	refs       []string
+	relay      *proto.Relay
+	log        ContractLogger
 }
 
 func (bs *batchStub) newTxStub(txID string, creatorSKI string) *batchTxStub {
@@ -76,6 +81,7 @@ func (bs *batchStub) newTxStub(txID string, creatorSKI string) *batchTxStub {
 		creatorSKI: creatorSKI,
 		txCache:    make(map[string]*proto.WriteElement),
 		events:     make(map[string][]byte),
+		log:        bs.log.With(LogFields{LogFieldTxID: txID}),
 	}
 }
 
@@ -106,13 +112,17 @@ func (bts *batchTxStub) SetEvent(name string, payload []byte) error {
 }
 
 func (bts *batchTxStub) AddAccountingRecord(token string, from *types.Address, to *types.Address, amount *big.Int, reason string) {
-	bts.accounting = append(bts.accounting, &proto.AccountingRecord{
+	record := &proto.AccountingRecord{
 		Token:     token,
 		Sender:    from.Bytes(),
 		Recipient: to.Bytes(),
 		Amount:    amount.Bytes(),
 		Reason:    reason,
-	})
+	}
+	if bts.relay != nil && bts.relay.Relayer != nil {
+		record.Relayer = bts.relay.Relayer.Address
+	}
+	bts.accounting = append(bts.accounting, record)
 }
 
 // Commit puts state from a batchTxStub cache to the batchStub cache
@@ -152,3 +162,22 @@ func (bts *batchTxStub) DelState(key string) error {
 	bts.txCache[key] = &proto.WriteElement{Key: key, IsDeleted: true}
 	return nil
 }
+
+// pendingKeys returns keys written in the batch which aren't committed to the state yet,
+// range queries don't see them, so they are read by the caller with GetState
+func pendingKeys(stub shim.ChaincodeStubInterface) []string {
+	var caches []map[string]*proto.WriteElement
+	switch s := stub.(type) {
+	case *batchTxStub:
+		caches = append(caches, s.txCache, s.batchCache)
+	case *batchStub:
+		caches = append(caches, s.batchCache)
+	}
+	keys := make([]string, 0)
+	for _, cache := range caches {
+		for key := range cache {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
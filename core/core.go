@@ -10,7 +10,6 @@ import (
 	"encoding/json"
 	"encoding/pem"
 	"fmt"
-	"log"
 	"reflect"
 	"runtime/debug"
 	"strings"
@@ -33,18 +32,25 @@ const (
 type NonceCheckFn func(shim.ChaincodeStubInterface, *types.Sender, uint64) error
 
 type ChainCode struct {
-	contract          BaseContractInterface
-	methods           map[string]*Fn
-	allowedMspID      string
-	checkInvokerBy    cib
-	disableSwaps      bool
-	init              *proto.InitArgs
-	disableMultiSwaps bool
-	txTTL             uint
-	batchPrefix       string
-	nonceTTL          uint
-	noncePrefix       StateKey
-	nonceCheckFn      NonceCheckFn
+	contract           BaseContractInterface
+	methods            map[string]*Fn
+	allowedMspID       string
+	checkInvokerBy     cib
+	disableSwaps       bool
+	init               *proto.InitArgs
+	disableMultiSwaps  bool
+	txTTL              uint
+	batchPrefix        string
+	nonceTTL           uint
+	noncePrefix        StateKey
+	nonceCheckFn       NonceCheckFn
+	multicall          bool
+	multiQuery         bool
+	methodTxTTL        map[string]uint
+	relay              bool
+	log                ContractLogger
+	swapConfig         swapConfig
+	legacySwapKeyEvent bool
 }
 
 func NewChainCode(cc BaseContractInterface, allowedMspID string, options *ContractOptions) (*ChainCode, error) {
@@ -60,7 +66,7 @@ func NewChainCode(cc BaseContractInterface, allowedMspID string, options *Contra
 		methods:      methods,
 		batchPrefix:  batchKey,
 		noncePrefix:  StateKeyNonce,
-		nonceCheckFn: checkNonce(0, StateKeyNonce),
+		log:          NewDefaultLogger(),
 	}
 
 	if options != nil {
@@ -68,6 +74,8 @@ func NewChainCode(cc BaseContractInterface, allowedMspID string, options *Contra
 		out.disableSwaps = options.DisableSwaps
 		out.disableMultiSwaps = options.DisableMultiSwaps
 		out.txTTL = options.TxTTL
+		out.methodTxTTL = options.MethodTxTTL
+		out.legacySwapKeyEvent = options.LegacySwapKeyEvent
 		if options.BatchPrefix != "" {
 			out.batchPrefix = options.BatchPrefix
 		}
@@ -77,8 +85,34 @@ func NewChainCode(cc BaseContractInterface, allowedMspID string, options *Contra
 		if options.IsOtherNoncePrefix {
 			out.noncePrefix = StateKeyPassedNonce
 		}
+		if options.Logger != nil {
+			out.log = options.Logger
+		}
+	}
+	out.log = out.log.With(LogFields{LogFieldChaincode: cc.GetID()})
+	cc.setLogger(out.log)
+	out.nonceCheckFn = checkNonce(out.nonceTTL, out.noncePrefix, out.log)
+	if out.swapConfig, err = newSwapConfig(options); err != nil {
+		return &ChainCode{}, err
+	}
+	cc.setSwapConfig(out.swapConfig)
+
+	if _, exists := methods[multicallMethod]; !exists && (options == nil || !options.DisableMulticall) {
+		methods[multicallMethod] = newMulticallFn()
+		out.multicall = true
+		cc.addMethod(multicallMethod)
+	}
 
-		out.nonceCheckFn = checkNonce(out.nonceTTL, out.noncePrefix)
+	if _, exists := methods[multiQueryMethod]; !exists && (options == nil || !options.DisableMultiQuery) {
+		methods[multiQueryMethod] = newMultiQueryFn()
+		out.multiQuery = true
+		cc.addMethod(multiQueryMethod)
+	}
+
+	if _, exists := methods[relayMethod]; !exists && (options == nil || !options.DisableRelay) {
+		methods[relayMethod] = newRelayFn()
+		out.relay = true
+		cc.addMethod(relayMethod)
 	}
 
 	return out, nil
@@ -138,9 +172,15 @@ func (cc *ChainCode) Init(stub shim.ChaincodeStubInterface) peer.Response {
 
 func (cc *ChainCode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response) { //nolint:gocognit,funlen
 	r = shim.Error("panic invoke")
+	f, args := stub.GetFunctionAndParameters()
+	logger := cc.log.With(LogFields{
+		LogFieldChannel: stub.GetChannelID(),
+		LogFieldTxID:    stub.GetTxID(),
+		LogFieldMethod:  f,
+	})
 	defer func() {
 		if rc := recover(); rc != nil {
-			log.Println("panic invoke\n" + string(debug.Stack()))
+			logger.Criticalf("panic invoke: %v\n%s", rc, string(debug.Stack()))
 		}
 	}()
 
@@ -180,7 +220,6 @@ func (cc *ChainCode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 	}
 	creatorSKI := sha256.Sum256(elliptic.Marshal(pk.Curve, pk.X, pk.Y))
 
-	f, args := stub.GetFunctionAndParameters()
 	switch f {
 	case "batchExecute":
 		hashedCert := sha3.Sum256(creator)
@@ -189,18 +228,29 @@ func (cc *ChainCode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 			return shim.Error("unauthorized")
 		}
 		return cc.batchExecute(stub, hex.EncodeToString(creatorSKI[:]), args[0])
+	case sweepExpiredSwapsMethod:
+		hashedCert := sha3.Sum256(creator)
+		if !bytes.Equal(hashedCert[:], cc.init.RobotSKI) &&
+			!bytes.Equal(creatorSKI[:], cc.init.RobotSKI) &&
+			!bytes.Equal(creatorSKI[:], cc.init.AtomyzeSKI) {
+			return shim.Error("unauthorized")
+		}
+		if len(args) == 0 {
+			return shim.Error("incorrect number of arguments")
+		}
+		return cc.sweepExecute(stub, hex.EncodeToString(creatorSKI[:]), args[0])
 	case "swapDone":
 		if cc.disableSwaps {
 			return shim.Error("swaps disabled")
 		}
 		_, contract := copyContract(cc.contract, stub, cc.allowedMspID, cc.init.AtomyzeSKI, cc.init.Args, cc.noncePrefix)
-		return swapUserDone(contract, args[0], args[1])
+		return swapUserDone(contract, args[0], args[1], cc.legacySwapKeyEvent)
 	case "multiSwapDone":
 		if cc.disableMultiSwaps {
 			return shim.Error("industrial swaps disabled")
 		}
 		_, contract := copyContract(cc.contract, stub, cc.allowedMspID, cc.init.AtomyzeSKI, cc.init.Args, cc.noncePrefix)
-		return multiSwapUserDone(contract, args[0], args[1])
+		return multiSwapUserDone(contract, args[0], args[1], cc.legacySwapKeyEvent)
 	}
 	method, exists := cc.methods[f]
 	if !exists {
@@ -219,7 +269,7 @@ func (cc *ChainCode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 		}
 	}
 	if method.noBatch {
-		sender, args, _, err := cc.checkAuthIfNeeds(stub, method, f, args, true)
+		sender, args, _, _, err := cc.checkAuthIfNeeds(stub, method, f, args, true)
 		if err != nil {
 			return shim.Error(err.Error())
 		}
@@ -227,14 +277,19 @@ func (cc *ChainCode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 		if err != nil {
 			return shim.Error(err.Error())
 		}
-		resp, err := cc.callMethod(stub, method, sender, args)
+		var resp []byte
+		if cc.isBuiltinMultiQuery(f) {
+			resp, err = cc.multiQueryExecute(stub, args)
+		} else {
+			resp, err = cc.callMethod(stub, method, sender, args)
+		}
 		if err != nil {
 			return shim.Error(err.Error())
 		}
 		return shim.Success(resp)
 	}
 
-	sender, args, nonce, err := cc.checkAuthIfNeeds(stub, method, f, args, true)
+	sender, args, nonce, deadline, err := cc.checkAuthIfNeeds(stub, method, f, args, true)
 	if err != nil {
 		return shim.Error(err.Error())
 	}
@@ -242,7 +297,18 @@ func (cc *ChainCode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 	if err != nil {
 		return shim.Error(err.Error())
 	}
-	if err = cc.saveToBatch(stub, f, creatorSKI[:], sender, args[:len(method.in)], nonce); err != nil {
+	if cc.isBuiltinMulticall(f) {
+		if args[0], err = cc.prepareMulticall(stub, args[0]); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+	if cc.isBuiltinRelay(f) {
+		if err = cc.saveRelayed(stub, creatorSKI[:], sender, nonce, deadline, args); err != nil {
+			return shim.Error(err.Error())
+		}
+		return shim.Success(nil)
+	}
+	if err = cc.saveToBatch(stub, f, creatorSKI[:], sender, args[:len(method.in)], nonce, deadline); err != nil {
 		return shim.Error(err.Error())
 	}
 	return shim.Success(nil)
@@ -388,5 +454,7 @@ func copyContract(
 		return cp, nil
 	}
 	contract.setStubAndInitArgs(stub, allowedMspID, atomyzeSKI, initArgs, noncePrefix)
+	// hooks of the base contract should refer to the copy with the current stub
+	contract.baseContractInit(contract)
 	return cp, contract
 }
@@ -0,0 +1,192 @@
+package core
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const (
+	DvpCompositeType = "dvp"
+	DvpReason        = "dvp"
+)
+
+// dvpLegLock locks the leg of the offer, the token of the contract is locked
+// in the token balance and other tokens in the allowed balance
+func (bc *BaseContract) dvpLegLock(address *types.Address, leg *proto.DvpLeg) error {
+	amount := new(big.Int).SetBytes(leg.Amount)
+	if leg.Token == bc.id {
+		return bc.TokenBalanceLock(address, amount)
+	}
+	return bc.AllowedBalanceLock(leg.Token, address, amount)
+}
+
+func (bc *BaseContract) dvpLegUnlock(address *types.Address, leg *proto.DvpLeg) error {
+	amount := new(big.Int).SetBytes(leg.Amount)
+	if leg.Token == bc.id {
+		return bc.TokenBalanceUnlock(address, amount)
+	}
+	return bc.AllowedBalanceUnLock(leg.Token, address, amount)
+}
+
+func (bc *BaseContract) dvpLegTransferLocked(from *types.Address, to *types.Address, leg *proto.DvpLeg) error {
+	amount := new(big.Int).SetBytes(leg.Amount)
+	if leg.Token == bc.id {
+		return bc.TokenBalanceTransferLocked(from, to, amount, DvpReason)
+	}
+	return bc.AllowedBalanceTransferLocked(leg.Token, from, to, amount, DvpReason)
+}
+
+func (bc *BaseContract) dvpLegTransfer(from *types.Address, to *types.Address, leg *proto.DvpLeg) error {
+	amount := new(big.Int).SetBytes(leg.Amount)
+	if leg.Token == bc.id {
+		return bc.TokenBalanceTransfer(from, to, amount, DvpReason)
+	}
+	return bc.AllowedBalanceTransfer(leg.Token, from, to, amount, DvpReason)
+}
+
+// TxDvpOffer creates delivery-versus-payment offer to the taker. The maker leg is locked
+// until the taker accepts the offer or the offer is cancelled, timeout is in seconds.
+func (bc *BaseContract) TxDvpOffer(
+	sender *types.Sender,
+	taker *types.Address,
+	makerToken string,
+	makerAmount *big.Int,
+	takerToken string,
+	takerAmount *big.Int,
+	timeout int64,
+) (string, error) {
+	if sender.Equal(taker) {
+		return "", errors.New("maker and taker should be different")
+	}
+	if makerToken == takerToken {
+		return "", errors.New("tokens of legs should be different")
+	}
+	if makerAmount.Sign() <= 0 || takerAmount.Sign() <= 0 {
+		return "", errors.New("amount should be positive")
+	}
+	if timeout <= 0 {
+		return "", errors.New("timeout should be positive")
+	}
+	id, err := hex.DecodeString(bc.GetStub().GetTxID())
+	if err != nil {
+		return "", err
+	}
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return "", err
+	}
+	d := &proto.Dvp{
+		Id:       id,
+		Maker:    sender.Address().Bytes(),
+		Taker:    taker.Bytes(),
+		MakerLeg: &proto.DvpLeg{Token: makerToken, Amount: makerAmount.Bytes()},
+		TakerLeg: &proto.DvpLeg{Token: takerToken, Amount: takerAmount.Bytes()},
+		Timeout:  ts.Seconds + timeout,
+	}
+	if err = bc.dvpLegLock(sender.Address(), d.MakerLeg); err != nil {
+		return "", err
+	}
+	if err = DvpSave(bc.GetStub(), bc.GetStub().GetTxID(), d); err != nil {
+		return "", err
+	}
+	return bc.GetStub().GetTxID(), nil
+}
+
+// TxDvpAccept settles the offer in one transaction: the taker leg goes to the maker
+// and the locked maker leg goes to the taker
+func (bc *BaseContract) TxDvpAccept(sender *types.Sender, dvpID string) error {
+	d, err := DvpLoad(bc.GetStub(), dvpID)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(d.Taker, sender.Address().Bytes()) {
+		return errors.New("unauthorized")
+	}
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	if d.Timeout <= ts.Seconds {
+		return errors.New("dvp offer is expired")
+	}
+	maker := types.AddrFromBytes(d.Maker)
+	if err = bc.dvpLegTransfer(sender.Address(), maker, d.TakerLeg); err != nil {
+		return err
+	}
+	if err = bc.dvpLegTransferLocked(maker, sender.Address(), d.MakerLeg); err != nil {
+		return err
+	}
+	return DvpDel(bc.GetStub(), dvpID)
+}
+
+// TxDvpCancel unlocks the maker leg. The maker can cancel the offer at any time,
+// anyone else only after the offer is expired.
+func (bc *BaseContract) TxDvpCancel(sender *types.Sender, dvpID string) error {
+	d, err := DvpLoad(bc.GetStub(), dvpID)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(d.Maker, sender.Address().Bytes()) {
+		ts, err := bc.GetStub().GetTxTimestamp()
+		if err != nil {
+			return err
+		}
+		if d.Timeout > ts.Seconds {
+			return errors.New("wait for timeout to end")
+		}
+	}
+	if err = bc.dvpLegUnlock(types.AddrFromBytes(d.Maker), d.MakerLeg); err != nil {
+		return err
+	}
+	return DvpDel(bc.GetStub(), dvpID)
+}
+
+func (bc *BaseContract) QueryDvpGet(dvpID string) (*proto.Dvp, error) {
+	return DvpLoad(bc.GetStub(), dvpID)
+}
+
+func DvpLoad(stub shim.ChaincodeStubInterface, dvpID string) (*proto.Dvp, error) {
+	key, err := stub.CreateCompositeKey(DvpCompositeType, []string{dvpID})
+	if err != nil {
+		return nil, err
+	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("dvp offer doesn't exist")
+	}
+	var d proto.Dvp
+	if err = pb.Unmarshal(data, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func DvpSave(stub shim.ChaincodeStubInterface, dvpID string, d *proto.Dvp) error {
+	key, err := stub.CreateCompositeKey(DvpCompositeType, []string{dvpID})
+	if err != nil {
+		return err
+	}
+	data, err := pb.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, data)
+}
+
+func DvpDel(stub shim.ChaincodeStubInterface, dvpID string) error {
+	key, err := stub.CreateCompositeKey(DvpCompositeType, []string{dvpID})
+	if err != nil {
+		return err
+	}
+	return stub.DelState(key)
+}
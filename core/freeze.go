@@ -0,0 +1,184 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const (
+	FreezeCompositeType = "freeze"
+
+	RegulatorEventName      = "regulator"
+	RegulatorActionFreeze   = "freeze"
+	RegulatorActionUnfreeze = "unfreeze"
+	RegulatorActionForced   = "forcedTransfer"
+)
+
+// freezeCheck returns error if the funds can't leave from or come to the address in this token.
+// Frozen address can't send funds, incoming transfers are blocked if the freeze is incoming,
+// nil address isn't checked. The freeze covers the token of the contract and its groups only,
+// allowed balances of other tokens are frozen by their own contracts.
+func (bc *BaseContract) freezeCheck(from *types.Address, to *types.Address) error {
+	if from != nil {
+		f, err := FreezeLoad(bc.stub, from)
+		if err != nil {
+			return err
+		}
+		if f != nil {
+			return fmt.Errorf("address %s is frozen", from.String())
+		}
+	}
+	if to != nil {
+		f, err := FreezeLoad(bc.stub, to)
+		if err != nil {
+			return err
+		}
+		if f != nil && f.Incoming {
+			return fmt.Errorf("address %s is frozen for incoming transfers", to.String())
+		}
+	}
+	return nil
+}
+
+// Freeze blocks outgoing transfers of the address in this token and incoming ones if incoming is set.
+// The freeze is put to the accounting with zero amount and to the audit event.
+func (bc *BaseContract) Freeze(sender *types.Address, address *types.Address, incoming bool, reasonCode string) error {
+	if err := FreezeSave(bc.stub, address, &proto.Freeze{Incoming: incoming, Reason: reasonCode}); err != nil {
+		return err
+	}
+	if stub, ok := bc.stub.(*batchTxStub); ok {
+		stub.AddAccountingRecord(bc.id, address, &types.Address{}, big.NewInt(0), RegulatorActionFreeze+": "+reasonCode)
+	}
+	return regulatorEventSet(bc.stub, &proto.RegulatorEvent{
+		Action:   RegulatorActionFreeze,
+		Sender:   sender.Bytes(),
+		Address:  address.Bytes(),
+		Token:    bc.id,
+		Incoming: incoming,
+		Reason:   reasonCode,
+	})
+}
+
+// Unfreeze removes the freeze of the address in this token
+func (bc *BaseContract) Unfreeze(sender *types.Address, address *types.Address, reasonCode string) error {
+	f, err := FreezeLoad(bc.stub, address)
+	if err != nil {
+		return err
+	}
+	if f == nil {
+		return errors.New("address isn't frozen")
+	}
+	if err = FreezeDel(bc.stub, address); err != nil {
+		return err
+	}
+	if stub, ok := bc.stub.(*batchTxStub); ok {
+		stub.AddAccountingRecord(bc.id, address, &types.Address{}, big.NewInt(0), RegulatorActionUnfreeze+": "+reasonCode)
+	}
+	return regulatorEventSet(bc.stub, &proto.RegulatorEvent{
+		Action:  RegulatorActionUnfreeze,
+		Sender:  sender.Bytes(),
+		Address: address.Bytes(),
+		Token:   bc.id,
+		Reason:  reasonCode,
+	})
+}
+
+// ForcedTransfer moves the amount regardless of the freeze of the addresses. The token of the contract
+// is moved in the token balance and groups of the token (token_group) in the industrial balance,
+// allowed balances of other tokens can't be moved by the regulator of this contract.
+func (bc *BaseContract) ForcedTransfer(
+	sender *types.Address,
+	token string,
+	from *types.Address,
+	to *types.Address,
+	amount *big.Int,
+	reasonCode string,
+) error {
+	accountingToken := bc.id
+	var path []string
+	switch {
+	case token == bc.id:
+	case strings.HasPrefix(token, bc.id+"_"):
+		parts := strings.Split(token, "_")
+		path = []string{parts[len(parts)-1]}
+		accountingToken = bc.id + "_" + path[0]
+	default:
+		return fmt.Errorf("token %s isn't issued by the contract", token)
+	}
+	if stub, ok := bc.stub.(*batchTxStub); ok {
+		stub.AddAccountingRecord(accountingToken, from, to, amount, RegulatorActionForced+": "+reasonCode)
+	}
+	if err := balanceTransfer(bc.stub, StateKeyTokenBalance, from, to, amount, path...); err != nil {
+		return err
+	}
+	return regulatorEventSet(bc.stub, &proto.RegulatorEvent{
+		Action:    RegulatorActionForced,
+		Sender:    sender.Bytes(),
+		Address:   from.Bytes(),
+		Recipient: to.Bytes(),
+		Token:     token,
+		Amount:    amount.Bytes(),
+		Reason:    reasonCode,
+	})
+}
+
+// regulatorEventSet sets the audit event, next regulator actions of the same batch transaction,
+// e.g. of a multicall, get numbered names (regulator_1, regulator_2...) so events aren't overwritten
+func regulatorEventSet(stub shim.ChaincodeStubInterface, event *proto.RegulatorEvent) error {
+	data, err := pb.Marshal(event)
+	if err != nil {
+		return err
+	}
+	name := RegulatorEventName
+	if txStub, ok := stub.(*batchTxStub); ok {
+		for i := 1; txStub.events[name] != nil; i++ {
+			name = RegulatorEventName + "_" + strconv.Itoa(i)
+		}
+	}
+	return stub.SetEvent(name, data)
+}
+
+// FreezeLoad returns the freeze of the address or nil if the address isn't frozen
+func FreezeLoad(stub shim.ChaincodeStubInterface, address *types.Address) (*proto.Freeze, error) {
+	key, err := stub.CreateCompositeKey(FreezeCompositeType, []string{address.String()})
+	if err != nil {
+		return nil, err
+	}
+	data, err := stub.GetState(key)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var f proto.Freeze
+	if err = pb.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func FreezeSave(stub shim.ChaincodeStubInterface, address *types.Address, f *proto.Freeze) error {
+	key, err := stub.CreateCompositeKey(FreezeCompositeType, []string{address.String()})
+	if err != nil {
+		return err
+	}
+	data, err := pb.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, data)
+}
+
+func FreezeDel(stub shim.ChaincodeStubInterface, address *types.Address) error {
+	key, err := stub.CreateCompositeKey(FreezeCompositeType, []string{address.String()})
+	if err != nil {
+		return err
+	}
+	return stub.DelState(key)
+}
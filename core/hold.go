@@ -0,0 +1,326 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const (
+	HoldCompositeType = "hold"
+	HoldExpiryIndex   = "hold_expiry"
+	HoldReason        = "hold"
+
+	ErrHoldNotFound = "hold doesn't exist"
+)
+
+// holdLock locks the amount of the hold. The token of the contract is locked in the token balance,
+// groups of the token (token_group) in the industrial balance and other tokens in the allowed balance.
+func (bc *BaseContract) holdLock(h *proto.Hold, amount *big.Int) error {
+	owner := types.AddrFromBytes(h.Owner)
+	switch {
+	case h.Token == bc.id:
+		return bc.TokenBalanceLock(owner, amount)
+	case strings.HasPrefix(h.Token, bc.id+"_"):
+		return bc.IndustrialBalanceLock(h.Token, owner, amount)
+	default:
+		return bc.AllowedBalanceLock(h.Token, owner, amount)
+	}
+}
+
+func (bc *BaseContract) holdUnlock(h *proto.Hold, amount *big.Int) error {
+	owner := types.AddrFromBytes(h.Owner)
+	switch {
+	case h.Token == bc.id:
+		return bc.TokenBalanceUnlock(owner, amount)
+	case strings.HasPrefix(h.Token, bc.id+"_"):
+		return bc.IndustrialBalanceUnLock(h.Token, owner, amount)
+	default:
+		return bc.AllowedBalanceUnLock(h.Token, owner, amount)
+	}
+}
+
+func (bc *BaseContract) holdTransferLocked(h *proto.Hold, to *types.Address, amount *big.Int) error {
+	owner := types.AddrFromBytes(h.Owner)
+	reason := h.Reason
+	if reason == "" {
+		reason = HoldReason
+	}
+	switch {
+	case h.Token == bc.id:
+		return bc.TokenBalanceTransferLocked(owner, to, amount, reason)
+	case strings.HasPrefix(h.Token, bc.id+"_"):
+		return bc.IndustrialBalanceTransferLocked(h.Token, owner, to, amount, reason)
+	default:
+		return bc.AllowedBalanceTransferLocked(h.Token, owner, to, amount, reason)
+	}
+}
+
+// TxHoldCreate locks the amount of the sender under the hold ID until the beneficiary captures
+// or releases it. Expiry is in seconds, after it the hold can't be captured and anyone can release it,
+// zero expiry means the hold doesn't expire. Expired holds of the sender are released before.
+func (bc *BaseContract) TxHoldCreate(
+	sender *types.Sender,
+	holdID string,
+	token string,
+	amount *big.Int,
+	beneficiary *types.Address,
+	expiry int64,
+	reason string,
+) error {
+	if holdID == "" {
+		return errors.New("hold id should be set")
+	}
+	if sender.Equal(beneficiary) {
+		return errors.New("owner and beneficiary should be different")
+	}
+	if amount.Sign() <= 0 {
+		return errors.New("amount should be positive")
+	}
+	if expiry < 0 {
+		return errors.New("expiry should be positive")
+	}
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	if err = bc.holdsReleaseExpired(sender.Address(), ts.Seconds); err != nil {
+		return err
+	}
+	_, err = HoldLoad(bc.GetStub(), sender.Address(), holdID)
+	if err == nil {
+		return fmt.Errorf("hold %s already exists", holdID)
+	}
+	if err.Error() != ErrHoldNotFound {
+		return err
+	}
+	h := &proto.Hold{
+		Id:          holdID,
+		Owner:       sender.Address().Bytes(),
+		Token:       token,
+		Amount:      amount.Bytes(),
+		Beneficiary: beneficiary.Bytes(),
+		Reason:      reason,
+	}
+	if expiry > 0 {
+		h.Expiry = ts.Seconds + expiry
+	}
+	if err = bc.holdLock(h, amount); err != nil {
+		return err
+	}
+	return HoldSave(bc.GetStub(), h)
+}
+
+// TxHoldCapture transfers the amount of the hold to the recipient, the rest of the hold remains locked.
+// Only the beneficiary can capture the hold before it's expired. Expired holds of the owner are released.
+func (bc *BaseContract) TxHoldCapture(
+	sender *types.Sender,
+	owner *types.Address,
+	holdID string,
+	to *types.Address,
+	amount *big.Int,
+) error {
+	h, err := HoldLoad(bc.GetStub(), owner, holdID)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(h.Beneficiary, sender.Address().Bytes()) {
+		return errors.New("unauthorized")
+	}
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	if holdExpired(h, ts.Seconds) {
+		return errors.New("hold is expired")
+	}
+	if err = bc.holdsReleaseExpired(owner, ts.Seconds); err != nil {
+		return err
+	}
+	if amount.Sign() <= 0 {
+		return errors.New("amount should be positive")
+	}
+	rest := new(big.Int).Sub(new(big.Int).SetBytes(h.Amount), amount)
+	if rest.Sign() < 0 {
+		return errors.New("amount exceeds the hold")
+	}
+	if err = bc.holdTransferLocked(h, to, amount); err != nil {
+		return err
+	}
+	if rest.Sign() == 0 {
+		return HoldDel(bc.GetStub(), h)
+	}
+	h.Amount = rest.Bytes()
+	return HoldSave(bc.GetStub(), h)
+}
+
+// TxHoldRelease unlocks the rest of the hold. The beneficiary can release the hold at any time,
+// anyone else only after the hold is expired. Expired holds of the owner are released as well.
+func (bc *BaseContract) TxHoldRelease(sender *types.Sender, owner *types.Address, holdID string) error {
+	h, err := HoldLoad(bc.GetStub(), owner, holdID)
+	if err != nil {
+		return err
+	}
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(h.Beneficiary, sender.Address().Bytes()) && !holdExpired(h, ts.Seconds) {
+		return errors.New("wait for hold to expire")
+	}
+	if err = bc.holdsReleaseExpired(owner, ts.Seconds); err != nil {
+		return err
+	}
+	if holdExpired(h, ts.Seconds) {
+		// the hold is released with other expired holds
+		return nil
+	}
+	return bc.holdRelease(h)
+}
+
+func (bc *BaseContract) holdRelease(h *proto.Hold) error {
+	if err := bc.holdUnlock(h, new(big.Int).SetBytes(h.Amount)); err != nil {
+		return err
+	}
+	return HoldDel(bc.GetStub(), h)
+}
+
+// holdsReleaseExpired releases expired holds of the owner
+func (bc *BaseContract) holdsReleaseExpired(owner *types.Address, now int64) error {
+	holds, err := HoldList(bc.GetStub(), owner)
+	if err != nil {
+		return err
+	}
+	for _, h := range holds {
+		if !holdExpired(h, now) {
+			continue
+		}
+		if err = bc.holdRelease(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func holdExpired(h *proto.Hold, now int64) bool {
+	return h.Expiry != 0 && h.Expiry <= now
+}
+
+func (bc *BaseContract) QueryHoldGet(owner *types.Address, holdID string) (*proto.Hold, error) {
+	return HoldLoad(bc.GetStub(), owner, holdID)
+}
+
+// QueryHolds returns holds of the owner including expired ones which are not released yet
+func (bc *BaseContract) QueryHolds(owner *types.Address) ([]*proto.Hold, error) {
+	return HoldList(bc.GetStub(), owner)
+}
+
+func HoldLoad(stub shim.ChaincodeStubInterface, owner *types.Address, holdID string) (*proto.Hold, error) {
+	key, err := stub.CreateCompositeKey(HoldCompositeType, []string{owner.String(), holdID})
+	if err != nil {
+		return nil, err
+	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New(ErrHoldNotFound)
+	}
+	var h proto.Hold
+	if err = pb.Unmarshal(data, &h); err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func HoldList(stub shim.ChaincodeStubInterface, owner *types.Address) ([]*proto.Hold, error) {
+	iter, err := stub.GetStateByPartialCompositeKey(HoldCompositeType, []string{owner.String()})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	holds := make([]*proto.Hold, 0)
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		// holds released in the current transaction are still returned by the iterator
+		data, err := stub.GetState(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		if data == nil {
+			continue
+		}
+		var h proto.Hold
+		if err = pb.Unmarshal(data, &h); err != nil {
+			return nil, err
+		}
+		holds = append(holds, &h)
+	}
+	return holds, nil
+}
+
+// HoldSave saves the hold, holds with expiry are indexed to be released by the robot
+func HoldSave(stub shim.ChaincodeStubInterface, h *proto.Hold) error {
+	key, err := stub.CreateCompositeKey(HoldCompositeType, []string{types.AddrFromBytes(h.Owner).String(), h.Id})
+	if err != nil {
+		return err
+	}
+	data, err := pb.Marshal(h)
+	if err != nil {
+		return err
+	}
+	if err = stub.PutState(key, data); err != nil {
+		return err
+	}
+	if h.Expiry == 0 {
+		return nil
+	}
+	expiryKey, err := holdExpiryKey(stub, h)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(expiryKey, []byte{0})
+}
+
+func HoldDel(stub shim.ChaincodeStubInterface, h *proto.Hold) error {
+	key, err := stub.CreateCompositeKey(HoldCompositeType, []string{types.AddrFromBytes(h.Owner).String(), h.Id})
+	if err != nil {
+		return err
+	}
+	if err = stub.DelState(key); err != nil {
+		return err
+	}
+	return holdExpiryDel(stub, h)
+}
+
+func holdExpiryDel(stub shim.ChaincodeStubInterface, h *proto.Hold) error {
+	if h.Expiry == 0 {
+		return nil
+	}
+	expiryKey, err := holdExpiryKey(stub, h)
+	if err != nil {
+		return err
+	}
+	return stub.DelState(expiryKey)
+}
+
+func holdExpiryKey(stub shim.ChaincodeStubInterface, h *proto.Hold) (string, error) {
+	return stub.CreateCompositeKey(
+		HoldExpiryIndex,
+		[]string{swapExpiryAttr(h.Expiry), types.AddrFromBytes(h.Owner).String(), h.Id},
+	)
+}
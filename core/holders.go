@@ -0,0 +1,157 @@
+package core
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+)
+
+const HoldersCompositeType = "holders"
+
+// holdingBalances returns balances which make up the holding of the token of the balance:
+// free, locked and vesting balances of the token of the contract and free and locked allowed balances.
+// Industrial balances aren't counted, nil is returned for them and for other state keys.
+func holdingBalances(tokenType StateKey, path []string) []StateKey {
+	switch tokenType {
+	case StateKeyTokenBalance, StateKeyLockedTokenBalance, StateKeyVestingBalance:
+		if len(path) == 0 {
+			return []StateKey{StateKeyTokenBalance, StateKeyLockedTokenBalance, StateKeyVestingBalance}
+		}
+	case StateKeyAllowedBalance, StateKeyLockedAllowedBalance:
+		if len(path) == 1 {
+			return []StateKey{StateKeyAllowedBalance, StateKeyLockedAllowedBalance}
+		}
+	}
+	return nil
+}
+
+// holding returns the sum of balances of the address which make up the holding
+func holding(stub shim.ChaincodeStubInterface, balances []StateKey, addr *types.Address, path []string) (*big.Int, error) {
+	total := big.NewInt(0)
+	for _, tokenType := range balances {
+		_, balance, err := balanceGet(stub, tokenType, addr, path...)
+		if err != nil {
+			return nil, err
+		}
+		total.Add(total, balance)
+	}
+	return total, nil
+}
+
+// holdersUpdate counts the address as the holder when its holding becomes positive and stops
+// counting it when the holding becomes zero, it's called before the balance is changed by delta
+func holdersUpdate(stub shim.ChaincodeStubInterface, tokenType StateKey, addr *types.Address, delta *big.Int, path []string) error {
+	balances := holdingBalances(tokenType, path)
+	if balances == nil || delta.Sign() == 0 {
+		return nil
+	}
+	before, err := holding(stub, balances, addr, path)
+	if err != nil {
+		return err
+	}
+	after := new(big.Int).Add(before, delta)
+	switch {
+	case before.Sign() == 0 && after.Sign() > 0:
+		return holdersAdd(stub, balances, path, 1)
+	case before.Sign() > 0 && after.Sign() == 0:
+		return holdersAdd(stub, balances, path, -1)
+	}
+	return nil
+}
+
+func holdersAdd(stub shim.ChaincodeStubInterface, balances []StateKey, path []string, n int) error {
+	holders, err := holdersGet(stub, balances, path)
+	if err != nil {
+		return err
+	}
+	key, err := stub.CreateCompositeKey(HoldersCompositeType, path)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, []byte(strconv.Itoa(holders+n)))
+}
+
+// holdersGet returns the number of holders of the token. The counter is kept in the state,
+// if it isn't there yet, e.g. after the upgrade, holders are counted by the scan of balances.
+func holdersGet(stub shim.ChaincodeStubInterface, balances []StateKey, path []string) (int, error) {
+	key, err := stub.CreateCompositeKey(HoldersCompositeType, path)
+	if err != nil {
+		return 0, err
+	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return 0, err
+	}
+	if data != nil {
+		return strconv.Atoi(string(data))
+	}
+	return holdersScan(stub, balances, path)
+}
+
+// holdersScan counts addresses with positive holding reading all balances of the token from the state.
+// Balances written earlier in the batch are counted as well.
+func holdersScan(stub shim.ChaincodeStubInterface, balances []StateKey, path []string) (int, error) {
+	keys := make([]string, 0)
+	for _, tokenType := range balances {
+		objectType := hex.EncodeToString([]byte{byte(tokenType)})
+		prefix, err := stub.CreateCompositeKey(objectType, []string{})
+		if err != nil {
+			return 0, err
+		}
+		iter, err := stub.GetStateByPartialCompositeKey(objectType, []string{})
+		if err != nil {
+			return 0, err
+		}
+		for iter.HasNext() {
+			kv, err := iter.Next()
+			if err != nil {
+				_ = iter.Close()
+				return 0, err
+			}
+			keys = append(keys, kv.Key)
+		}
+		_ = iter.Close()
+		for _, key := range pendingKeys(stub) {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+	}
+
+	holdings := make(map[string]*big.Int)
+	counted := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if counted[key] {
+			continue
+		}
+		counted[key] = true
+		_, keyParts, err := stub.SplitCompositeKey(key)
+		if err != nil {
+			return 0, err
+		}
+		if len(keyParts) != len(path)+1 || (len(path) == 1 && keyParts[1] != path[0]) {
+			continue
+		}
+		// the balance can be changed or deleted in the batch
+		value, err := stub.GetState(key)
+		if err != nil {
+			return 0, err
+		}
+		if holdings[keyParts[0]] == nil {
+			holdings[keyParts[0]] = big.NewInt(0)
+		}
+		holdings[keyParts[0]].Add(holdings[keyParts[0]], new(big.Int).SetBytes(value))
+	}
+
+	holders := 0
+	for _, amount := range holdings {
+		if amount.Sign() > 0 {
+			holders++
+		}
+	}
+	return holders, nil
+}
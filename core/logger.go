@@ -1,7 +1,14 @@
 package core
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/op/go-logging"
 )
@@ -32,3 +39,161 @@ func Logger() *logging.Logger {
 	}
 	return lg
 }
+
+// Log entry context fields
+const (
+	LogFieldChannel   = "channel"
+	LogFieldChaincode = "chaincode"
+	LogFieldBatchID   = "batchID"
+	LogFieldTxID      = "txID"
+	LogFieldMethod    = "method"
+	LogFieldSender    = "sender"
+	LogFieldElapsedMs = "elapsedMs"
+)
+
+// LogFields - context of log entries
+type LogFields map[string]interface{}
+
+// ContractLogger - logger used by the chaincode. It can be set with ContractOptions.Logger
+type ContractLogger interface {
+	// With returns logger which adds fields to every entry
+	With(fields LogFields) ContractLogger
+	Debugf(format string, args ...interface{})
+	Infof(format string, args ...interface{})
+	Warningf(format string, args ...interface{})
+	Errorf(format string, args ...interface{})
+	Criticalf(format string, args ...interface{})
+}
+
+// defaultLogger writes entries to the process-global go-logging logger,
+// fields are added to the end of the message
+type defaultLogger struct {
+	logger *logging.Logger
+	fields LogFields
+}
+
+// NewDefaultLogger returns logger based on Logger() configured from the environment
+func NewDefaultLogger() ContractLogger {
+	l := *Logger()
+	l.ExtraCalldepth = 1
+	return &defaultLogger{logger: &l}
+}
+
+func (l *defaultLogger) With(fields LogFields) ContractLogger {
+	return &defaultLogger{logger: l.logger, fields: mergeLogFields(l.fields, fields)}
+}
+
+func (l *defaultLogger) format(format string) string {
+	if len(l.fields) == 0 {
+		return format
+	}
+	keys := sortedLogFields(l.fields)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", k, l.fields[k]))
+	}
+	return format + " [" + strings.ReplaceAll(strings.Join(parts, " "), "%", "%%") + "]"
+}
+
+func (l *defaultLogger) Debugf(format string, args ...interface{}) {
+	l.logger.Debugf(l.format(format), args...)
+}
+
+func (l *defaultLogger) Infof(format string, args ...interface{}) {
+	l.logger.Infof(l.format(format), args...)
+}
+
+func (l *defaultLogger) Warningf(format string, args ...interface{}) {
+	l.logger.Warningf(l.format(format), args...)
+}
+
+func (l *defaultLogger) Errorf(format string, args ...interface{}) {
+	l.logger.Errorf(l.format(format), args...)
+}
+
+func (l *defaultLogger) Criticalf(format string, args ...interface{}) {
+	l.logger.Criticalf(l.format(format), args...)
+}
+
+// jsonLogger writes every entry as a single line json object with all context fields
+type jsonLogger struct {
+	out    io.Writer
+	mu     *sync.Mutex
+	level  logging.Level
+	fields LogFields
+}
+
+// NewJSONLogger returns logger which writes entries with level up to the given one
+// (critical, error, warning, notice, info, debug) as json lines to out
+func NewJSONLogger(out io.Writer, level string) (ContractLogger, error) {
+	lvl, err := logging.LogLevel(level)
+	if err != nil {
+		return nil, err
+	}
+	return &jsonLogger{out: out, mu: &sync.Mutex{}, level: lvl}, nil
+}
+
+func (l *jsonLogger) With(fields LogFields) ContractLogger {
+	return &jsonLogger{out: l.out, mu: l.mu, level: l.level, fields: mergeLogFields(l.fields, fields)}
+}
+
+func (l *jsonLogger) write(level logging.Level, format string, args ...interface{}) {
+	if level > l.level {
+		return
+	}
+	entry := make(map[string]interface{}, len(l.fields)+3) //nolint:gomnd
+	for k, v := range l.fields {
+		entry[k] = v
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["message"] = fmt.Sprintf(format, args...)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		data = []byte(fmt.Sprintf(`{"level":"ERROR","message":%q}`, err.Error()))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.out.Write(append(data, '\n'))
+}
+
+func (l *jsonLogger) Debugf(format string, args ...interface{}) {
+	l.write(logging.DEBUG, format, args...)
+}
+
+func (l *jsonLogger) Infof(format string, args ...interface{}) {
+	l.write(logging.INFO, format, args...)
+}
+
+func (l *jsonLogger) Warningf(format string, args ...interface{}) {
+	l.write(logging.WARNING, format, args...)
+}
+
+func (l *jsonLogger) Errorf(format string, args ...interface{}) {
+	l.write(logging.ERROR, format, args...)
+}
+
+func (l *jsonLogger) Criticalf(format string, args ...interface{}) {
+	l.write(logging.CRITICAL, format, args...)
+}
+
+func mergeLogFields(base LogFields, fields LogFields) LogFields {
+	merged := make(LogFields, len(base)+len(fields))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return merged
+}
+
+func sortedLogFields(fields LogFields) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
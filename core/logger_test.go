@@ -0,0 +1,37 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONLogger(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger, err := NewJSONLogger(buf, "info")
+	assert.NoError(t, err)
+
+	batchLogger := logger.With(LogFields{LogFieldChannel: "ch", LogFieldBatchID: "b1"})
+	batchLogger.With(LogFields{LogFieldTxID: "t1", LogFieldElapsedMs: 5}).Infof("tx %s done", "t1")
+	batchLogger.Debugf("skipped")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 1)
+
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "INFO", entry["level"])
+	assert.Equal(t, "tx t1 done", entry["message"])
+	assert.Equal(t, "ch", entry[LogFieldChannel])
+	assert.Equal(t, "b1", entry[LogFieldBatchID])
+	assert.Equal(t, "t1", entry[LogFieldTxID])
+	assert.Equal(t, float64(5), entry[LogFieldElapsedMs])
+}
+
+func TestJSONLoggerWrongLevel(t *testing.T) {
+	_, err := NewJSONLogger(&bytes.Buffer{}, "verbose")
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const multicallMethod = "multicall"
+
+// txIDMethods create objects keyed by the transaction ID. All calls of the multicall share the ID,
+// so the second object would overwrite the first one, and only one such call is allowed.
+var txIDMethods = map[string]bool{
+	"swapBegin":                 true,
+	"swapBeginWithTimeout":      true,
+	"swapBeginTo":               true,
+	"swapBeginRoute":            true,
+	"multiSwapBegin":            true,
+	"multiSwapBeginWithTimeout": true,
+	"multiSwapBeginTo":          true,
+	"dvpOffer":                  true,
+	"createVesting":             true,
+}
+
+// Call is one item of the multicall argument
+type Call struct {
+	Method string   `json:"method"`
+	Args   []string `json:"args"`
+}
+
+// newMulticallFn describes the built-in multicall method.
+// It takes a single JSON argument with the list of calls and must be signed.
+func newMulticallFn() *Fn {
+	return &Fn{
+		needsAuth: true,
+		in: []In{{
+			kind:          reflect.TypeOf(""),
+			convertToCall: reflect.ValueOf(types.BaseTypes["string"]),
+		}},
+		out: true,
+	}
+}
+
+func (cc *ChainCode) isBuiltinMulticall(method string) bool {
+	return method == multicallMethod && cc.multicall
+}
+
+func (cc *ChainCode) parseCalls(raw string) ([]Call, error) {
+	var calls []Call
+	if err := json.Unmarshal([]byte(raw), &calls); err != nil {
+		return nil, err
+	}
+	if len(calls) == 0 {
+		return nil, errors.New("calls can't be empty")
+	}
+	txIDCall := ""
+	for _, call := range calls {
+		if call.Method == multicallMethod {
+			return nil, errors.New("nested multicall is not allowed")
+		}
+		method, exists := cc.methods[call.Method]
+		if !exists {
+			return nil, fmt.Errorf("unknown method %s", call.Method)
+		}
+		// built-in methods have no function to call and are executed by the chaincode itself
+		if method.noBatch || cc.isBuiltinRelay(call.Method) || cc.isBuiltinMultiQuery(call.Method) || !method.fn.IsValid() {
+			return nil, fmt.Errorf("method %s can't be called in multicall", call.Method)
+		}
+		if txIDMethods[call.Method] {
+			if txIDCall != "" {
+				return nil, fmt.Errorf("methods %s and %s can't be called in the same multicall", txIDCall, call.Method)
+			}
+			txIDCall = call.Method
+		}
+	}
+	return calls, nil
+}
+
+// prepareMulticall checks every call of the multicall and converts its arguments
+// the same way as Invoke does for a single method
+func (cc *ChainCode) prepareMulticall(stub shim.ChaincodeStubInterface, raw string) (string, error) {
+	calls, err := cc.parseCalls(raw)
+	if err != nil {
+		return "", err
+	}
+	for i, call := range calls {
+		if calls[i].Args, err = doPrepareToSave(stub, cc.methods[call.Method], call.Args); err != nil {
+			return "", fmt.Errorf("call %d (%s): %w", i, call.Method, err)
+		}
+	}
+	data, err := json.Marshal(calls)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// multicallExecute executes all calls in the same batchTxStub, so either all writes
+// are committed by the caller or none of them
+func (cc *ChainCode) multicallExecute(stub *batchTxStub, sender *proto.Address, args []string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, errors.New("incorrect number of arguments")
+	}
+	calls, err := cc.parseCalls(args[0])
+	if err != nil {
+		return nil, err
+	}
+	results := make([]json.RawMessage, 0, len(calls))
+	for i, call := range calls {
+		method := cc.methods[call.Method]
+		var callSender *proto.Address
+		if method.needsAuth {
+			callSender = sender
+		}
+		resp, err := cc.callMethod(stub, method, callSender, call.Args)
+		if err != nil {
+			return nil, fmt.Errorf("call %d (%s): %w", i, call.Method, err)
+		}
+		if resp == nil {
+			resp = []byte("null")
+		}
+		results = append(results, resp)
+	}
+	return json.Marshal(results)
+}
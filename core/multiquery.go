@@ -0,0 +1,84 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+)
+
+const multiQueryMethod = "multiQuery"
+
+// QueryResult is one item of the multiQuery response
+type QueryResult struct {
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// newMultiQueryFn describes the built-in multiQuery method.
+// It takes a single JSON argument with the list of calls of query methods.
+func newMultiQueryFn() *Fn {
+	return &Fn{
+		query:   true,
+		noBatch: true,
+		in: []In{{
+			kind:          reflect.TypeOf(""),
+			convertToCall: reflect.ValueOf(types.BaseTypes["string"]),
+		}},
+		out: true,
+	}
+}
+
+func (cc *ChainCode) isBuiltinMultiQuery(method string) bool {
+	return method == multiQueryMethod && cc.multiQuery
+}
+
+// multiQueryExecute calls every query method from the list and collects
+// results or errors for each of them. An error of one call doesn't break the others.
+func (cc *ChainCode) multiQueryExecute(stub shim.ChaincodeStubInterface, args []string) ([]byte, error) {
+	if len(args) == 0 {
+		return nil, errors.New("incorrect number of arguments")
+	}
+	var calls []Call
+	if err := json.Unmarshal([]byte(args[0]), &calls); err != nil {
+		return nil, err
+	}
+	if len(calls) == 0 {
+		return nil, errors.New("calls can't be empty")
+	}
+
+	results := make([]QueryResult, 0, len(calls))
+	for _, call := range calls {
+		res := QueryResult{Method: call.Method}
+		resp, err := cc.queryCall(stub, call)
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.Result = resp
+		}
+		results = append(results, res)
+	}
+	return json.Marshal(results)
+}
+
+func (cc *ChainCode) queryCall(stub shim.ChaincodeStubInterface, call Call) ([]byte, error) {
+	method, exists := cc.methods[call.Method]
+	if !exists {
+		return nil, fmt.Errorf("unknown method %s", call.Method)
+	}
+	if !method.query || method.needsAuth || cc.isBuiltinMultiQuery(call.Method) {
+		return nil, fmt.Errorf("method %s can't be called in multiQuery", call.Method)
+	}
+	resp, err := cc.callMethod(stub, method, nil, call.Args)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		resp = []byte("null")
+	}
+	return resp, nil
+}
@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"encoding/hex"
 	"errors"
-	"log"
+	"fmt"
 	"runtime/debug"
 	"strings"
 
@@ -23,11 +23,11 @@ const (
 	MultiSwapKeyEvent      = "multi_swap_key"
 )
 
-func multiSwapAnswer(stub *batchStub, creatorSKI string, swap *proto.MultiSwap) (r *proto.SwapResponse) {
+func multiSwapAnswer(stub *batchStub, creatorSKI string, swap *proto.MultiSwap, timeout int64) (r *proto.SwapResponse) {
 	r = &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: "panic swapAnswer"}}
 	defer func() {
 		if rc := recover(); rc != nil {
-			log.Println("panic swapAnswer: " + hex.EncodeToString(swap.Id) + "\n" + string(debug.Stack()))
+			stub.log.Criticalf("panic swapAnswer: %s\n%s", hex.EncodeToString(swap.Id), string(debug.Stack()))
 		}
 	}()
 
@@ -37,17 +37,23 @@ func multiSwapAnswer(stub *batchStub, creatorSKI string, swap *proto.MultiSwap)
 	}
 	txStub := stub.newTxStub(hex.EncodeToString(swap.Id), creatorSKI)
 
-	swap.Creator = []byte("0000")
-	swap.Timeout = ts.Seconds + robotSideTimeout
+	if swap.Timeout, err = answerTimeout(ts.Seconds, timeout, swap.Timeout); err != nil {
+		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	swap.Creator = robotCreator
 
 	switch {
 	case swap.Token == swap.From:
 		// nothing to do
 	case swap.Token == swap.To:
+		// the value is reserved till the swap is done, so the limit isn't freed by the refundable answer
 		for _, asset := range swap.Assets {
 			if err = GivenBalanceSub(txStub, swap.From, new(big.Int).SetBytes(asset.Amount)); err != nil {
 				return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
 			}
+			if err = givenBalanceReservedAdd(txStub, swap.From, new(big.Int).SetBytes(asset.Amount)); err != nil {
+				return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+			}
 		}
 	default:
 		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: ErrIncorrectSwap}}
@@ -56,15 +62,24 @@ func multiSwapAnswer(stub *batchStub, creatorSKI string, swap *proto.MultiSwap)
 	if _, err = MultiSwapSave(txStub, hex.EncodeToString(swap.Id), swap); err != nil {
 		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
 	}
-	writes, _ := txStub.Commit()
-	return &proto.SwapResponse{Id: swap.Id, Writes: writes}
+	if err = swapLifecycleSet(txStub, swap.Id, true, SwapStatusAnswered, swap.Timeout); err != nil {
+		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	if err = swapIndexAdd(txStub, newMultiSwapIndexEntry(swap)); err != nil {
+		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	if err = setSwapEvent(txStub, SwapAnsweredEvent, newMultiSwapEvent(swap)); err != nil {
+		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	writes, events := txStub.Commit()
+	return &proto.SwapResponse{Id: swap.Id, Writes: writes, Events: events}
 }
 
 func multiSwapRobotDone(stub *batchStub, creatorSKI string, swapID []byte, key string) (r *proto.SwapResponse) {
 	r = &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: "panic swapRobotDone"}}
 	defer func() {
 		if rc := recover(); rc != nil {
-			log.Println("panic swapRobotDone: " + hex.EncodeToString(swapID) + "\n" + string(debug.Stack()))
+			stub.log.Criticalf("panic swapRobotDone: %s\n%s", hex.EncodeToString(swapID), string(debug.Stack()))
 		}
 	}()
 
@@ -83,17 +98,33 @@ func multiSwapRobotDone(stub *batchStub, creatorSKI string, swapID []byte, key s
 			if err = GivenBalanceAdd(txStub, swap.To, new(big.Int).SetBytes(asset.Amount)); err != nil {
 				return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
 			}
+			if err = givenBalanceReservedSub(txStub, swap.To, new(big.Int).SetBytes(asset.Amount)); err != nil {
+				return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
+			}
 		}
 	}
 
 	if err = MultiSwapDel(txStub, hex.EncodeToString(swapID)); err != nil {
 		return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
 	}
-	writes, _ := txStub.Commit()
-	return &proto.SwapResponse{Id: swapID, Writes: writes}
+	if err = swapLifecycleSet(txStub, swapID, true, SwapStatusRobotDone, 0); err != nil {
+		return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	if err = swapIndexDel(txStub, newMultiSwapIndexEntry(swap)); err != nil {
+		return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	event := newMultiSwapEvent(swap)
+	event.Key = key
+	if err = setSwapEvent(txStub, SwapKeyRevealedEvent, event); err != nil {
+		return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	writes, events := txStub.Commit()
+	return &proto.SwapResponse{Id: swapID, Writes: writes, Events: events}
 }
 
-func multiSwapUserDone(bc BaseContractInterface, swapID string, key string) peer.Response {
+// multiSwapUserDone completes the multiswap with the key revealed by the user,
+// the legacy multi_swap_key event is set instead of the typed one if required
+func multiSwapUserDone(bc BaseContractInterface, swapID string, key string, legacyKeyEvent bool) peer.Response {
 	swap, err := MultiSwapLoad(bc.GetStub(), swapID)
 	if err != nil {
 		return shim.Error(err.Error())
@@ -103,7 +134,7 @@ func multiSwapUserDone(bc BaseContractInterface, swapID string, key string) peer
 		return shim.Error(ErrIncorrectKey)
 	}
 
-	if bytes.Equal(swap.Creator, swap.Owner) {
+	if !swapAnswered(swap.Creator) {
 		return shim.Error(ErrIncorrectSwap)
 	}
 
@@ -113,7 +144,16 @@ func multiSwapUserDone(bc BaseContractInterface, swapID string, key string) peer
 		}
 	} else {
 		for _, asset := range swap.Assets {
-			if err = bc.IndustrialBalanceAdd(asset.Group, types.AddrFromBytes(swap.Owner), new(big.Int).SetBytes(asset.Amount), MultiSwapReason); err != nil {
+			amount := new(big.Int).SetBytes(asset.Amount)
+			if asset.Group == swap.Token {
+				err = bc.TokenBalanceAdd(types.AddrFromBytes(swap.Owner), amount, MultiSwapReason)
+			} else {
+				err = bc.IndustrialBalanceAdd(asset.Group, types.AddrFromBytes(swap.Owner), amount, MultiSwapReason)
+			}
+			if err != nil {
+				return shim.Error(err.Error())
+			}
+			if err = givenBalanceReservedSub(bc.GetStub(), swap.From, amount); err != nil {
 				return shim.Error(err.Error())
 			}
 		}
@@ -122,9 +162,22 @@ func multiSwapUserDone(bc BaseContractInterface, swapID string, key string) peer
 	if err = MultiSwapDel(bc.GetStub(), swapID); err != nil {
 		return shim.Error(err.Error())
 	}
-	e := strings.Join([]string{swap.From, swapID, key}, "\t")
-	if err = bc.GetStub().SetEvent(MultiSwapKeyEvent, []byte(e)); err != nil {
-		shim.Error(err.Error())
+	if err = swapLifecycleSet(bc.GetStub(), swap.Id, true, SwapStatusUserDone, 0); err != nil {
+		return shim.Error(err.Error())
+	}
+	if err = swapIndexDel(bc.GetStub(), newMultiSwapIndexEntry(swap)); err != nil {
+		return shim.Error(err.Error())
+	}
+	if legacyKeyEvent {
+		e := strings.Join([]string{swap.From, swapID, key}, "\t")
+		err = bc.GetStub().SetEvent(MultiSwapKeyEvent, []byte(e))
+	} else {
+		event := newMultiSwapEvent(swap)
+		event.Key = key
+		err = setSwapEvent(bc.GetStub(), SwapKeyRevealedEvent, event)
+	}
+	if err != nil {
+		return shim.Error(err.Error())
 	}
 	return shim.Success(nil)
 }
@@ -138,6 +191,47 @@ func (bc *BaseContract) QueryMultiSwapGet(swapID string) (*proto.MultiSwap, erro
 }
 
 func (bc *BaseContract) TxMultiSwapBegin(sender *types.Sender, token string, multiSwapAssets types.MultiSwapAssets, contractTo string, hash types.Hex) (string, error) {
+	return bc.multiSwapBegin(sender, token, multiSwapAssets, contractTo, hash, sender.Address(), bc.swapConfig.userSide())
+}
+
+// TxMultiSwapBeginWithTimeout begins multiswap with the user side timeout in seconds
+// within the bounds set by ContractOptions
+func (bc *BaseContract) TxMultiSwapBeginWithTimeout(
+	sender *types.Sender,
+	token string,
+	multiSwapAssets types.MultiSwapAssets,
+	contractTo string,
+	hash types.Hex,
+	timeout int64,
+) (string, error) {
+	if err := bc.swapConfig.checkUserSide(timeout); err != nil {
+		return "", err
+	}
+	return bc.multiSwapBegin(sender, token, multiSwapAssets, contractTo, hash, sender.Address(), timeout)
+}
+
+// TxMultiSwapBeginTo begins multiswap which is credited to the recipient on the other side,
+// the sender remains the creator and can cancel the multiswap
+func (bc *BaseContract) TxMultiSwapBeginTo(
+	sender *types.Sender,
+	token string,
+	multiSwapAssets types.MultiSwapAssets,
+	contractTo string,
+	hash types.Hex,
+	recipient *types.Address,
+) (string, error) {
+	return bc.multiSwapBegin(sender, token, multiSwapAssets, contractTo, hash, recipient, bc.swapConfig.userSide())
+}
+
+func (bc *BaseContract) multiSwapBegin(
+	sender *types.Sender,
+	token string,
+	multiSwapAssets types.MultiSwapAssets,
+	contractTo string,
+	hash types.Hex,
+	recipient *types.Address,
+	timeout int64,
+) (string, error) {
 	id, err := hex.DecodeString(bc.GetStub().GetTxID())
 	if err != nil {
 		return "", err
@@ -153,28 +247,38 @@ func (bc *BaseContract) TxMultiSwapBegin(sender *types.Sender, token string, mul
 	if len(assets) == 0 {
 		return "", errors.New("assets can't be empty")
 	}
+	if err = multiSwapAssetsCheck(token, assets); err != nil {
+		return "", err
+	}
 
 	swap := proto.MultiSwap{
 		Id:      id,
 		Creator: sender.Address().Bytes(),
-		Owner:   sender.Address().Bytes(),
+		Owner:   recipient.Bytes(),
 		Assets:  assets,
 		Token:   token,
 		From:    bc.id,
 		To:      contractTo,
 		Hash:    hash,
-		Timeout: ts.Seconds + userSideTimeout,
+		Timeout: ts.Seconds + timeout,
 	}
 
 	switch {
 	case swap.Token == swap.From:
+		total := new(big.Int)
 		for _, asset := range swap.Assets {
-			if err = bc.tokenBalanceSub(types.AddrFromBytes(swap.Owner), new(big.Int).SetBytes(asset.Amount), asset.Group); err != nil {
+			total.Add(total, new(big.Int).SetBytes(asset.Amount))
+		}
+		if err = givenBalanceReserve(bc.GetStub(), swap.To, total); err != nil {
+			return "", err
+		}
+		for _, asset := range swap.Assets {
+			if err = bc.tokenBalanceSub(sender.Address(), new(big.Int).SetBytes(asset.Amount), asset.Group); err != nil {
 				return "", err
 			}
 		}
 	case swap.Token == swap.To:
-		if err = bc.AllowedIndustrialBalanceSub(types.AddrFromBytes(swap.Owner), swap.Assets, MultiSwapReason); err != nil {
+		if err = bc.AllowedIndustrialBalanceSub(sender.Address(), swap.Assets, MultiSwapReason); err != nil {
 			return "", err
 		}
 	default:
@@ -185,6 +289,15 @@ func (bc *BaseContract) TxMultiSwapBegin(sender *types.Sender, token string, mul
 	if err != nil {
 		return "", err
 	}
+	if err = swapLifecycleSet(bc.GetStub(), swap.Id, true, SwapStatusCreated, swap.Timeout); err != nil {
+		return "", err
+	}
+	if err = swapIndexAdd(bc.GetStub(), newMultiSwapIndexEntry(&swap)); err != nil {
+		return "", err
+	}
+	if err = setSwapEvent(bc.GetStub(), SwapCreatedEvent, newMultiSwapEvent(&swap)); err != nil {
+		return "", err
+	}
 
 	if btchTxStub, ok := bc.stub.(*batchTxStub); ok {
 		btchTxStub.multiSwaps = append(btchTxStub.multiSwaps, &swap)
@@ -192,6 +305,22 @@ func (bc *BaseContract) TxMultiSwapBegin(sender *types.Sender, token string, mul
 	return bc.GetStub().GetTxID(), nil
 }
 
+// multiSwapAssetsCheck checks that assets are the base token or its industrial groups,
+// so the whole basket is moved under one hash
+func multiSwapAssetsCheck(token string, assets []*proto.Asset) error {
+	groups := make(map[string]struct{}, len(assets))
+	for _, asset := range assets {
+		if asset.Group != token && !strings.HasPrefix(asset.Group, token+"_") {
+			return fmt.Errorf("asset %s doesn't belong to token %s", asset.Group, token)
+		}
+		if _, ok := groups[asset.Group]; ok {
+			return fmt.Errorf("duplicate asset %s", asset.Group)
+		}
+		groups[asset.Group] = struct{}{}
+	}
+	return nil
+}
+
 func (bc *BaseContract) TxMultiSwapCancel(sender *types.Sender, swapID string) error {
 	swap, err := MultiSwapLoad(bc.GetStub(), swapID)
 	if err != nil {
@@ -207,29 +336,51 @@ func (bc *BaseContract) TxMultiSwapCancel(sender *types.Sender, swapID string) e
 	if swap.Timeout > ts.Seconds {
 		return errors.New("wait for timeout to end")
 	}
+	return multiSwapRefund(bc, swap)
+}
+
+// multiSwapRefund returns funds of the canceled or expired multiswap and deletes it
+func multiSwapRefund(bc swapBalances, swap *proto.MultiSwap) error {
+	var err error
 	switch {
-	case bytes.Equal(swap.Creator, swap.Owner) && swap.Token == swap.From:
+	case !swapAnswered(swap.Creator) && swap.Token == swap.From:
 		for _, asset := range swap.Assets {
-			if err = bc.tokenBalanceAdd(types.AddrFromBytes(swap.Owner), new(big.Int).SetBytes(asset.Amount), asset.Group); err != nil {
+			amount := new(big.Int).SetBytes(asset.Amount)
+			if stub, ok := bc.GetStub().(*batchTxStub); ok {
+				stub.AddAccountingRecord(asset.Group, &types.Address{}, types.AddrFromBytes(swap.Creator), amount, MultiSwapReason)
+			}
+			if err = bc.tokenBalanceAdd(types.AddrFromBytes(swap.Creator), amount, asset.Group); err != nil {
+				return err
+			}
+			if err = givenBalanceReservedSub(bc.GetStub(), swap.To, amount); err != nil {
 				return err
 			}
 		}
-	case bytes.Equal(swap.Creator, swap.Owner) && swap.Token == swap.To:
-		if err = bc.AllowedIndustrialBalanceAdd(types.AddrFromBytes(swap.Owner), swap.Assets, MultiSwapReason); err != nil {
+	case !swapAnswered(swap.Creator) && swap.Token == swap.To:
+		if err = bc.allowedIndustrialBalanceAdd(types.AddrFromBytes(swap.Creator), swap.Assets, MultiSwapReason); err != nil {
 			return err
 		}
-	case bytes.Equal(swap.Creator, []byte("0000")) && swap.Token == swap.To:
+	case swapAnswered(swap.Creator) && swap.Token == swap.To:
 		for _, asset := range swap.Assets {
 			if err = GivenBalanceAdd(bc.GetStub(), swap.From, new(big.Int).SetBytes(asset.Amount)); err != nil {
 				return err
 			}
+			if err = givenBalanceReservedSub(bc.GetStub(), swap.From, new(big.Int).SetBytes(asset.Amount)); err != nil {
+				return err
+			}
 		}
 	}
 
-	if err = MultiSwapDel(bc.GetStub(), swapID); err != nil {
+	if err = MultiSwapDel(bc.GetStub(), hex.EncodeToString(swap.Id)); err != nil {
 		return err
 	}
-	return nil
+	if err = swapIndexDel(bc.GetStub(), newMultiSwapIndexEntry(swap)); err != nil {
+		return err
+	}
+	if err = setSwapEvent(bc.GetStub(), SwapCancelledEvent, newMultiSwapEvent(swap)); err != nil {
+		return err
+	}
+	return swapLifecycleSet(bc.GetStub(), swap.Id, true, SwapStatusCancelled, 0)
 }
 
 func MultiSwapLoad(stub shim.ChaincodeStubInterface, swapID string) (*proto.MultiSwap, error) {
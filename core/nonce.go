@@ -19,7 +19,7 @@ const (
 	lenTimeInMilliseconds = 13
 )
 
-func checkNonce(nonceTTL uint, prefix StateKey) NonceCheckFn {
+func checkNonce(nonceTTL uint, prefix StateKey, log ContractLogger) NonceCheckFn {
 	return func(stub shim.ChaincodeStubInterface, sender *types.Sender, nonce uint64) error {
 		noncePrefix := hex.EncodeToString([]byte{byte(prefix)})
 		nonceKey, err := stub.CreateCompositeKey(noncePrefix, []string{sender.Address().String()})
@@ -34,8 +34,8 @@ func checkNonce(nonceTTL uint, prefix StateKey) NonceCheckFn {
 		lastNonce := new(pb.Nonce)
 		if len(data) > 0 {
 			if err = proto.Unmarshal(data, lastNonce); err != nil {
-				logger := Logger()
-				logger.Warningf("error unmarshal nonce, maybe old nonce. error: %v", err)
+				log.With(LogFields{LogFieldTxID: stub.GetTxID()}).
+					Warningf("error unmarshal nonce, maybe old nonce. error: %v", err)
 				// предположим, что это старый нонс
 				lastNonce.Nonce = []uint64{new(big.Int).SetBytes(data).Uint64()}
 			}
@@ -10,6 +10,8 @@ const (
 // ContractOptions
 // TxTTL - Время жизни транзакции в секундах. По умолчанию 0 - вечная жизнь.
 // Проверяется при исполнении батча. В US равно 30 секунд.
+// MethodTxTTL - время жизни транзакции в секундах для отдельных методов, переопределяет TxTTL.
+// Ключ - имя метода в том виде, в каком он вызывается (например "transfer"), 0 - вечная жизнь.
 // BatchPrefix - префик с которым в hlf сохраняются преимаджи. По умолчанию "batchTransactions"
 // US задает свой более короткий префикс из одного или двух символов
 // NonceTTL - время в секундах для nonce. Если пытаемся выполнить в батче транзакцию,
@@ -18,13 +20,45 @@ const (
 // Если NonceTTL = 0, то проверка происходит "по старому" при добавлении преимаджа.
 // IsOtherNoncePrefix - исторически сложилось, что для нонсов в atomyze-us используется другой префикс.
 // Поддержать разные префиксы мы обязаны, но плодить их не стоит. Поэтому только флаг.
+// DisableMulticall - отключает встроенный метод multicall, который исполняет несколько
+// методов контракта одной подписанной транзакцией атомарно.
+// DisableMultiQuery - отключает встроенный метод multiQuery, который за один запрос
+// вызывает несколько query методов и возвращает массив результатов.
+// DisableRelay - отключает встроенный метод relay, через который релейер отправляет
+// транзакцию, подписанную пользователем, и платит за нее комиссию.
+// SwapUserSideTimeout - время в секундах, после которого создатель может отменить свап
+// или мультисвап. По умолчанию 10800 (3 часа).
+// SwapRobotSideTimeout - таймаут свапа в секундах на стороне канала, куда его принес робот.
+// По умолчанию 300 (5 минут). Таймаут на стороне пользователя должен быть больше него
+// хотя бы на 60 секунд, иначе NewChainCode вернет ошибку.
+// SwapMinUserSideTimeout, SwapMaxUserSideTimeout - границы таймаута, который пользователь может
+// задать для отдельного свапа в swapBeginWithTimeout и multiSwapBeginWithTimeout.
+// Если SwapMaxUserSideTimeout = 0, задавать таймаут для отдельного свапа нельзя, иначе
+// SwapMinUserSideTimeout тоже должен быть больше таймаута на стороне робота хотя бы на 60 секунд.
+// LegacySwapKeyEvent - при завершении свапа пользователем отправлять старое событие "key"
+// ("multi_swap_key" для мультисвапа) со строкой From\tswapID\tkey вместо swapKeyRevealed.
+// Fabric сохраняет только одно событие на транзакцию, поэтому отправляется одно из них.
+// Logger - логгер контракта. По умолчанию пишет в Logger() с настройками из окружения,
+// для структурированных логов в json можно передать NewJSONLogger. К каждой записи
+// добавляются channel, chaincode, batchID, txID, method и sender, если они известны.
 type ContractOptions struct {
 	DisabledFunctions  []string
 	CheckInvokerBy     cib
 	DisableSwaps       bool
 	DisableMultiSwaps  bool
 	TxTTL              uint
+	MethodTxTTL        map[string]uint
 	BatchPrefix        string
 	NonceTTL           uint
 	IsOtherNoncePrefix bool
+	DisableMulticall   bool
+	DisableMultiQuery  bool
+	DisableRelay       bool
+	Logger             ContractLogger
+	LegacySwapKeyEvent bool
+
+	SwapUserSideTimeout    uint
+	SwapRobotSideTimeout   uint
+	SwapMinUserSideTimeout uint
+	SwapMaxUserSideTimeout uint
 }
@@ -35,3 +35,17 @@ func TestDisabledFunctions(t *testing.T) {
 	_, exists2 := cc2.methods["testFunction"]
 	assert.False(t, exists2)
 }
+
+func TestDisableMulticall(t *testing.T) {
+	cc1, err := NewChainCode(&testContract{}, "", nil)
+	assert.NoError(t, err)
+	_, exists1 := cc1.methods[multicallMethod]
+	assert.True(t, exists1)
+
+	cc2, err := NewChainCode(&testContract{}, "", &ContractOptions{
+		DisableMulticall: true,
+	})
+	assert.NoError(t, err)
+	_, exists2 := cc2.methods[multicallMethod]
+	assert.False(t, exists2)
+}
@@ -0,0 +1,18 @@
+package core
+
+// WithRateVersion runs the deal made by the rate and puts the version of the rate
+// into the accounting records of the deal
+func (bc *BaseContract) WithRateVersion(version uint64, deal func() error) error {
+	txStub, isBatch := bc.stub.(*batchTxStub)
+	if !isBatch {
+		return deal()
+	}
+	start := len(txStub.accounting)
+	if err := deal(); err != nil {
+		return err
+	}
+	for _, record := range txStub.accounting[start:] {
+		record.RateVersion = version
+	}
+	return nil
+}
@@ -24,6 +24,18 @@ type Fn struct {
 	out       bool
 }
 
+// methods skipped when swaps or multiswaps are disabled by ContractOptions
+var (
+	swapMethods = []string{
+		"QuerySwapGet", "QuerySwapsByOwner", "QuerySwapsByChannel",
+		"TxSwapBegin", "TxSwapBeginWithTimeout", "TxSwapBeginTo", "TxSwapBeginRoute", "TxSwapCancel",
+	}
+	multiSwapMethods = []string{
+		"QueryMultiSwapGet", "QueryMultiSwapsByOwner", "QueryMultiSwapsByChannel",
+		"TxMultiSwapBegin", "TxMultiSwapBeginWithTimeout", "TxMultiSwapBeginTo", "TxMultiSwapCancel",
+	}
+)
+
 //nolint:gocognit
 func ParseContract(in BaseContractInterface, options *ContractOptions) (map[string]*Fn, error) {
 	out := make(map[string]*Fn)
@@ -35,12 +47,10 @@ func ParseContract(in BaseContractInterface, options *ContractOptions) (map[stri
 		if options != nil && contains(options.DisabledFunctions, method.Name) {
 			continue
 		}
-		if options != nil && options.DisableSwaps && (method.Name == "QuerySwapGet" ||
-			method.Name == "TxSwapBegin" || method.Name == "TxSwapCancel") {
+		if options != nil && options.DisableSwaps && contains(swapMethods, method.Name) {
 			continue
 		}
-		if options != nil && options.DisableMultiSwaps && (method.Name == "QueryMultiSwapGet" ||
-			method.Name == "TxMultiSwapBegin" || method.Name == "TxMultiSwapCancel") {
+		if options != nil && options.DisableMultiSwaps && contains(multiSwapMethods, method.Name) {
 			continue
 		}
 
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/btcsuite/btcutil/base58"
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const relayMethod = "relay"
+
+// Relay describes the relayer which pays fees for a transaction signed by another sender
+type Relay struct {
+	Relayer     *types.Address
+	FeeCurrency string
+}
+
+// newRelayFn describes the built-in relay method. It is signed by the relayer and takes
+// the signed inner call (base58 encoded proto.Nested) and the currency of the fee.
+func newRelayFn() *Fn {
+	str := In{
+		kind:          reflect.TypeOf(""),
+		convertToCall: reflect.ValueOf(types.BaseTypes["string"]),
+	}
+	return &Fn{
+		needsAuth: true,
+		in:        []In{str, str},
+	}
+}
+
+func (cc *ChainCode) isBuiltinRelay(method string) bool {
+	return method == relayMethod && cc.relay
+}
+
+// saveRelayed checks the inner call signed by the sender and saves it to the batch
+// together with the relayer agreed to pay fees
+func (cc *ChainCode) saveRelayed(
+	stub shim.ChaincodeStubInterface,
+	creatorSKI []byte,
+	relayer *proto.Address,
+	relayerNonce uint64,
+	deadline int64,
+	args []string,
+) error {
+	var nested proto.Nested
+	if err := pb.Unmarshal(base58.Decode(args[0]), &nested); err != nil {
+		return err
+	}
+	if len(nested.Args) == 0 {
+		return errors.New("empty relayed call")
+	}
+
+	fn := nested.Args[0]
+	method, exists := cc.methods[fn]
+	if !exists {
+		return fmt.Errorf("unknown method %s", fn)
+	}
+	if cc.isBuiltinRelay(fn) || method.noBatch || !method.needsAuth {
+		return fmt.Errorf("method %s can't be relayed", fn)
+	}
+
+	sender, innerArgs, nonce, innerDeadline, err := cc.checkAuthIfNeeds(stub, method, fn, nested.Args[1:], true)
+	if err != nil {
+		return err
+	}
+	if innerArgs, err = doPrepareToSave(stub, method, innerArgs); err != nil {
+		return err
+	}
+	if cc.isBuiltinMulticall(fn) {
+		if innerArgs[0], err = cc.prepareMulticall(stub, innerArgs[0]); err != nil {
+			return err
+		}
+	}
+	if deadline == 0 || (innerDeadline != 0 && innerDeadline < deadline) {
+		deadline = innerDeadline
+	}
+
+	return cc.putToBatch(stub, &proto.PendingTx{
+		Method:     fn,
+		Sender:     sender,
+		Args:       innerArgs,
+		CreatorSKI: creatorSKI,
+		Nonce:      nonce,
+		Deadline:   deadline,
+		Relay: &proto.Relay{
+			Relayer:     relayer,
+			Nonce:       relayerNonce,
+			FeeCurrency: args[1],
+		},
+	})
+}
+
+// GetRelay returns the relayer of the current batched transaction
+// or nil if the transaction isn't relayed
+func (bc *BaseContract) GetRelay() *Relay {
+	stub, ok := bc.stub.(*batchTxStub)
+	if !ok || stub.relay == nil {
+		return nil
+	}
+	return &Relay{
+		Relayer:     (*types.Address)(stub.relay.Relayer),
+		FeeCurrency: stub.relay.FeeCurrency,
+	}
+}
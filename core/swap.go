@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"encoding/hex"
 	"errors"
-	"log"
 	"runtime/debug"
 	"strings"
 
@@ -21,15 +20,23 @@ const (
 	ErrIncorrectSwap = "incorrect swap"
 	ErrIncorrectKey  = "incorrect key"
 
+	// default timeouts, can be changed with ContractOptions
 	userSideTimeout  = 10800 // 3 hours
 	robotSideTimeout = 300   // 5 minutes
+
+	// swapSettleMargin is the time in seconds the robot has to finish the swap on the user side
+	// after the robot side is done, the user side timeout can't be shorter than the robot side plus the margin
+	swapSettleMargin = 60 // 1 minute
 )
 
-func swapAnswer(stub *batchStub, creatorSKI string, swap *proto.Swap) (r *proto.SwapResponse) {
+// robotCreator is the creator of swaps answered by the robot
+var robotCreator = []byte("0000")
+
+func swapAnswer(stub *batchStub, creatorSKI string, swap *proto.Swap, timeout int64) (r *proto.SwapResponse) {
 	r = &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: "panic swapAnswer"}}
 	defer func() {
 		if rc := recover(); rc != nil {
-			log.Println("panic swapAnswer: " + hex.EncodeToString(swap.Id) + "\n" + string(debug.Stack()))
+			stub.log.Criticalf("panic swapAnswer: %s\n%s", hex.EncodeToString(swap.Id), string(debug.Stack()))
 		}
 	}()
 
@@ -39,32 +46,60 @@ func swapAnswer(stub *batchStub, creatorSKI string, swap *proto.Swap) (r *proto.
 	}
 	txStub := stub.newTxStub(hex.EncodeToString(swap.Id), creatorSKI)
 
-	swap.Creator = []byte("0000")
-	swap.Timeout = ts.Seconds + robotSideTimeout
+	prevTimeout := swap.Timeout
+	if swap.Timeout, err = answerTimeout(ts.Seconds, timeout, prevTimeout); err != nil {
+		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	swap.Creator = robotCreator
+	// the fee is kept on the side where the swap begins
+	swap.Fee, swap.FeeCurrency, swap.FeeLegs = nil, "", nil
+	swap.ForwardedFrom = ""
 
 	switch {
 	case swap.TokenSymbol() == swap.From:
 		// nothing to do
 	case swap.TokenSymbol() == swap.To:
+		// the value is reserved till the swap is done, so the limit isn't freed by the refundable answer
 		if err = GivenBalanceSub(txStub, swap.From, new(big.Int).SetBytes(swap.Amount)); err != nil {
 			return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
 		}
+		if err = givenBalanceReservedAdd(txStub, swap.From, new(big.Int).SetBytes(swap.Amount)); err != nil {
+			return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+		}
 	default:
 		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: ErrIncorrectSwap}}
 	}
+	if len(swap.Route) > 0 {
+		if err = swapForward(txStub, swap, ts.Seconds, timeout, prevTimeout); err != nil {
+			return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+		}
+	}
 
 	if _, err = SwapSave(txStub, hex.EncodeToString(swap.Id), swap); err != nil {
 		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
 	}
-	writes, _ := txStub.Commit()
-	return &proto.SwapResponse{Id: swap.Id, Writes: writes}
+	if err = swapLifecycleSet(txStub, swap.Id, false, SwapStatusAnswered, swap.Timeout); err != nil {
+		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	if err = swapIndexAdd(txStub, newSwapIndexEntry(swap)); err != nil {
+		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	if err = setSwapEvent(txStub, SwapAnsweredEvent, newSwapEvent(swap)); err != nil {
+		return &proto.SwapResponse{Id: swap.Id, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	if swap.ForwardedFrom != "" {
+		// the robot answers the next hop like a swap begun in this batch
+		stub.swaps = append(stub.swaps, swap)
+	}
+	writes, events := txStub.Commit()
+	return &proto.SwapResponse{Id: swap.Id, Writes: writes, Events: events}
 }
 
 func swapRobotDone(stub *batchStub, creatorSKI string, swapID []byte, key string) (r *proto.SwapResponse) {
 	r = &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: "panic swapRobotDone"}}
 	defer func() {
 		if rc := recover(); rc != nil {
-			log.Println("panic swapRobotDone: " + hex.EncodeToString(swapID) + "\n" + string(debug.Stack()))
+			stub.log.Criticalf("panic swapRobotDone: %s\n%s", hex.EncodeToString(swapID), string(debug.Stack()))
 		}
 	}()
 
@@ -82,15 +117,39 @@ func swapRobotDone(stub *batchStub, creatorSKI string, swapID []byte, key string
 		if err = GivenBalanceAdd(txStub, s.To, new(big.Int).SetBytes(s.Amount)); err != nil {
 			return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
 		}
+		if err = givenBalanceReservedSub(txStub, s.To, new(big.Int).SetBytes(s.Amount)); err != nil {
+			return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
+		}
+	}
+	if s.ForwardedFrom != "" {
+		if err = givenBalanceReservedSub(txStub, s.ForwardedFrom, new(big.Int).SetBytes(s.Amount)); err != nil {
+			return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
+		}
+	}
+	if err = swapFeeRelease(txStub, s); err != nil {
+		return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
 	}
 	if err = SwapDel(txStub, hex.EncodeToString(swapID)); err != nil {
 		return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
 	}
-	writes, _ := txStub.Commit()
-	return &proto.SwapResponse{Id: swapID, Writes: writes}
+	if err = swapLifecycleSet(txStub, swapID, false, SwapStatusRobotDone, 0); err != nil {
+		return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	if err = swapIndexDel(txStub, newSwapIndexEntry(s)); err != nil {
+		return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	event := newSwapEvent(s)
+	event.Key = key
+	if err = setSwapEvent(txStub, SwapKeyRevealedEvent, event); err != nil {
+		return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	writes, events := txStub.Commit()
+	return &proto.SwapResponse{Id: swapID, Writes: writes, Events: events}
 }
 
-func swapUserDone(bc BaseContractInterface, swapID string, key string) peer.Response {
+// swapUserDone completes the swap with the key revealed by the user. Fabric keeps one event
+// per transaction, so the legacy "key" event is set instead of the typed one if required.
+func swapUserDone(bc BaseContractInterface, swapID string, key string, legacyKeyEvent bool) peer.Response {
 	s, err := SwapLoad(bc.GetStub(), swapID)
 	if err != nil {
 		return shim.Error(err.Error())
@@ -100,7 +159,7 @@ func swapUserDone(bc BaseContractInterface, swapID string, key string) peer.Resp
 		return shim.Error(ErrIncorrectKey)
 	}
 
-	if bytes.Equal(s.Creator, s.Owner) {
+	if !swapAnswered(s.Creator) || s.ForwardedFrom != "" {
 		return shim.Error(ErrIncorrectSwap)
 	}
 	if s.TokenSymbol() == s.From {
@@ -108,16 +167,40 @@ func swapUserDone(bc BaseContractInterface, swapID string, key string) peer.Resp
 			return shim.Error(err.Error())
 		}
 	} else {
+		if err = bc.freezeCheck(nil, types.AddrFromBytes(s.Owner)); err != nil {
+			return shim.Error(err.Error())
+		}
+		if s.Token == bc.GetID() {
+			if err = bc.checkTransferRules(s.Token, nil, types.AddrFromBytes(s.Owner), new(big.Int).SetBytes(s.Amount)); err != nil {
+				return shim.Error(err.Error())
+			}
+		}
 		if err = bc.tokenBalanceAdd(types.AddrFromBytes(s.Owner), new(big.Int).SetBytes(s.Amount), s.Token); err != nil {
 			return shim.Error(err.Error())
 		}
+		if err = givenBalanceReservedSub(bc.GetStub(), s.From, new(big.Int).SetBytes(s.Amount)); err != nil {
+			return shim.Error(err.Error())
+		}
 	}
 	if err = SwapDel(bc.GetStub(), swapID); err != nil {
 		return shim.Error(err.Error())
 	}
-	e := strings.Join([]string{s.From, swapID, key}, "\t")
-	if err = bc.GetStub().SetEvent("key", []byte(e)); err != nil {
-		shim.Error(err.Error())
+	if err = swapLifecycleSet(bc.GetStub(), s.Id, false, SwapStatusUserDone, 0); err != nil {
+		return shim.Error(err.Error())
+	}
+	if err = swapIndexDel(bc.GetStub(), newSwapIndexEntry(s)); err != nil {
+		return shim.Error(err.Error())
+	}
+	if legacyKeyEvent {
+		e := strings.Join([]string{s.From, swapID, key}, "\t")
+		err = bc.GetStub().SetEvent("key", []byte(e))
+	} else {
+		event := newSwapEvent(s)
+		event.Key = key
+		err = setSwapEvent(bc.GetStub(), SwapKeyRevealedEvent, event)
+	}
+	if err != nil {
+		return shim.Error(err.Error())
 	}
 	return shim.Success(nil)
 }
@@ -131,6 +214,48 @@ func (bc *BaseContract) QuerySwapGet(swapID string) (*proto.Swap, error) {
 }
 
 func (bc *BaseContract) TxSwapBegin(sender *types.Sender, token string, contractTo string, amount *big.Int, hash types.Hex) (string, error) {
+	return bc.swapBegin(sender, token, contractTo, amount, hash, sender.Address(), bc.swapConfig.userSide(), nil)
+}
+
+// TxSwapBeginWithTimeout begins swap with the user side timeout in seconds
+// within the bounds set by ContractOptions
+func (bc *BaseContract) TxSwapBeginWithTimeout(
+	sender *types.Sender,
+	token string,
+	contractTo string,
+	amount *big.Int,
+	hash types.Hex,
+	timeout int64,
+) (string, error) {
+	if err := bc.swapConfig.checkUserSide(timeout); err != nil {
+		return "", err
+	}
+	return bc.swapBegin(sender, token, contractTo, amount, hash, sender.Address(), timeout, nil)
+}
+
+// TxSwapBeginTo begins swap which is credited to the recipient on the other side,
+// the sender remains the creator and can cancel the swap
+func (bc *BaseContract) TxSwapBeginTo(
+	sender *types.Sender,
+	token string,
+	contractTo string,
+	amount *big.Int,
+	hash types.Hex,
+	recipient *types.Address,
+) (string, error) {
+	return bc.swapBegin(sender, token, contractTo, amount, hash, recipient, bc.swapConfig.userSide(), nil)
+}
+
+func (bc *BaseContract) swapBegin(
+	sender *types.Sender,
+	token string,
+	contractTo string,
+	amount *big.Int,
+	hash types.Hex,
+	recipient *types.Address,
+	timeout int64,
+	route []string,
+) (string, error) {
 	id, err := hex.DecodeString(bc.GetStub().GetTxID())
 	if err != nil {
 		return "", err
@@ -142,32 +267,48 @@ func (bc *BaseContract) TxSwapBegin(sender *types.Sender, token string, contract
 	s := proto.Swap{
 		Id:      id,
 		Creator: sender.Address().Bytes(),
-		Owner:   sender.Address().Bytes(),
+		Owner:   recipient.Bytes(),
 		Token:   token,
 		Amount:  amount.Bytes(),
 		From:    bc.id,
 		To:      contractTo,
 		Hash:    hash,
-		Timeout: ts.Seconds + userSideTimeout,
+		Timeout: ts.Seconds + timeout,
+		Route:   route,
 	}
 
 	switch {
 	case s.TokenSymbol() == s.From:
-		if err = bc.tokenBalanceSub(types.AddrFromBytes(s.Owner), amount, s.Token); err != nil {
+		if err = givenBalanceReserve(bc.GetStub(), s.To, amount); err != nil {
+			return "", err
+		}
+		if err = bc.tokenBalanceSub(sender.Address(), amount, s.Token); err != nil {
 			return "", err
 		}
 	case s.TokenSymbol() == s.To:
-		if err = bc.AllowedBalanceSub(s.Token, types.AddrFromBytes(s.Owner), amount, "swap"); err != nil {
+		if err = bc.AllowedBalanceSub(s.Token, sender.Address(), amount, "swap"); err != nil {
 			return "", err
 		}
 	default:
 		return "", errors.New(ErrIncorrectSwap)
 	}
+	if err = bc.swapFeeCharge(sender.Address(), &s, amount); err != nil {
+		return "", err
+	}
 
 	_, err = SwapSave(bc.GetStub(), bc.GetStub().GetTxID(), &s)
 	if err != nil {
 		return "", err
 	}
+	if err = swapLifecycleSet(bc.GetStub(), s.Id, false, SwapStatusCreated, s.Timeout); err != nil {
+		return "", err
+	}
+	if err = swapIndexAdd(bc.GetStub(), newSwapIndexEntry(&s)); err != nil {
+		return "", err
+	}
+	if err = setSwapEvent(bc.GetStub(), SwapCreatedEvent, newSwapEvent(&s)); err != nil {
+		return "", err
+	}
 
 	if btchTxStub, ok := bc.stub.(*batchTxStub); ok {
 		btchTxStub.swaps = append(btchTxStub.swaps, &s)
@@ -190,24 +331,88 @@ func (bc *BaseContract) TxSwapCancel(sender *types.Sender, swapID string) error
 	if s.Timeout > ts.Seconds {
 		return errors.New("wait for timeout to end")
 	}
+	return swapRefund(bc, s)
+}
+
+// answerTimeout returns the timeout of the answer to the swap with the timeout prev. The answer ends
+// the settle margin before the swap it answers, so after the key is revealed on the answer the robot
+// has time to finish the previous swap before it can be refunded. Timeouts decrease along the route.
+func answerTimeout(now int64, timeout int64, prev int64) (int64, error) {
+	answer := now + timeout
+	// zero means the previous timeout isn't known
+	if limit := prev - swapSettleMargin; prev != 0 && answer > limit {
+		answer = limit
+	}
+	if answer <= now {
+		return 0, errors.New("swap timeout is too short to answer")
+	}
+	return answer, nil
+}
+
+// swapAnswered reports whether the swap was created by the robot on the answer side
+func swapAnswered(creator []byte) bool {
+	return bytes.Equal(creator, robotCreator)
+}
+
+// swapBalances is the part of the contract used to return funds of swaps
+type swapBalances interface {
+	GetStub() shim.ChaincodeStubInterface
+	tokenBalanceAdd(address *types.Address, amount *big.Int, token string) error
+	allowedBalanceAdd(token string, address *types.Address, amount *big.Int, reason string) error
+	allowedIndustrialBalanceAdd(address *types.Address, industrialAssets []*proto.Asset, reason string) error
+}
+
+// swapRefund returns funds of the canceled or expired swap and deletes it
+func swapRefund(bc swapBalances, s *proto.Swap) error {
+	var err error
+	amount := new(big.Int).SetBytes(s.Amount)
 	switch {
-	case bytes.Equal(s.Creator, s.Owner) && s.TokenSymbol() == s.From:
-		if err = bc.tokenBalanceAdd(types.AddrFromBytes(s.Owner), new(big.Int).SetBytes(s.Amount), s.Token); err != nil {
+	case s.ForwardedFrom != "":
+		// the value came from the previous hop of the route
+		if err = GivenBalanceAdd(bc.GetStub(), s.ForwardedFrom, amount); err != nil {
 			return err
 		}
-	case bytes.Equal(s.Creator, s.Owner) && s.TokenSymbol() == s.To:
-		if err = bc.AllowedBalanceAdd(s.Token, types.AddrFromBytes(s.Owner), new(big.Int).SetBytes(s.Amount), "swap"); err != nil {
+		if err = givenBalanceReservedSub(bc.GetStub(), s.ForwardedFrom, amount); err != nil {
+			return err
+		}
+		if err = givenBalanceReservedSub(bc.GetStub(), s.To, amount); err != nil {
+			return err
+		}
+	case !swapAnswered(s.Creator) && s.TokenSymbol() == s.From:
+		if err = givenBalanceReservedSub(bc.GetStub(), s.To, amount); err != nil {
+			return err
+		}
+		if stub, ok := bc.GetStub().(*batchTxStub); ok {
+			stub.AddAccountingRecord(s.Token, &types.Address{}, types.AddrFromBytes(s.Creator), amount, "swap")
+		}
+		if err = bc.tokenBalanceAdd(types.AddrFromBytes(s.Creator), amount, s.Token); err != nil {
 			return err
 		}
-	case bytes.Equal(s.Creator, []byte("0000")) && s.TokenSymbol() == s.To:
-		if err = GivenBalanceAdd(bc.GetStub(), s.From, new(big.Int).SetBytes(s.Amount)); err != nil {
+	case !swapAnswered(s.Creator) && s.TokenSymbol() == s.To:
+		if err = bc.allowedBalanceAdd(s.Token, types.AddrFromBytes(s.Creator), amount, "swap"); err != nil {
+			return err
+		}
+	case swapAnswered(s.Creator) && s.TokenSymbol() == s.To:
+		if err = GivenBalanceAdd(bc.GetStub(), s.From, amount); err != nil {
+			return err
+		}
+		if err = givenBalanceReservedSub(bc.GetStub(), s.From, amount); err != nil {
 			return err
 		}
 	}
-	if err = SwapDel(bc.GetStub(), swapID); err != nil {
+	if err = swapFeeRefund(bc.GetStub(), s); err != nil {
+		return err
+	}
+	if err = SwapDel(bc.GetStub(), hex.EncodeToString(s.Id)); err != nil {
+		return err
+	}
+	if err = swapIndexDel(bc.GetStub(), newSwapIndexEntry(s)); err != nil {
+		return err
+	}
+	if err = setSwapEvent(bc.GetStub(), SwapCancelledEvent, newSwapEvent(s)); err != nil {
 		return err
 	}
-	return nil
+	return swapLifecycleSet(bc.GetStub(), s.Id, false, SwapStatusCancelled, 0)
 }
 
 func SwapLoad(stub shim.ChaincodeStubInterface, swapID string) (*proto.Swap, error) {
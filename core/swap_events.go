@@ -0,0 +1,63 @@
+package core
+
+import (
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+// Names of swap and multiswap events, the payload is proto.SwapEvent
+const (
+	SwapCreatedEvent     = "swapCreated"
+	SwapAnsweredEvent    = "swapAnswered"
+	SwapKeyRevealedEvent = "swapKeyRevealed"
+	SwapCancelledEvent   = "swapCancelled"
+)
+
+func newSwapEvent(s *proto.Swap) *proto.SwapEvent {
+	return &proto.SwapEvent{
+		Id:      s.Id,
+		Creator: s.Creator,
+		Owner:   s.Owner,
+		Token:   s.Token,
+		Amount:  s.Amount,
+		From:    s.From,
+		To:      s.To,
+		Hash:    s.Hash,
+		Timeout: s.Timeout,
+
+		Route:         s.Route,
+		ForwardedFrom: s.ForwardedFrom,
+	}
+}
+
+func newMultiSwapEvent(s *proto.MultiSwap) *proto.SwapEvent {
+	return &proto.SwapEvent{
+		Id:      s.Id,
+		Multi:   true,
+		Creator: s.Creator,
+		Owner:   s.Owner,
+		Token:   s.Token,
+		Assets:  s.Assets,
+		From:    s.From,
+		To:      s.To,
+		Hash:    s.Hash,
+		Timeout: s.Timeout,
+	}
+}
+
+func setSwapEvent(stub shim.ChaincodeStubInterface, name string, event *proto.SwapEvent) error {
+	data, err := pb.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return stub.SetEvent(name, data)
+}
+
+// swapBatchEvent puts events of the swap processed by the robot to the batch event
+func swapBatchEvent(method string, resp *proto.SwapResponse) *proto.BatchTxEvent {
+	if resp.Error != nil || len(resp.Events) == 0 {
+		return nil
+	}
+	return &proto.BatchTxEvent{Id: resp.Id, Method: method, Events: resp.Events}
+}
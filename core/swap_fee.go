@@ -0,0 +1,146 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const (
+	SwapFeeReason              = "swap fee"
+	SwapFeeEscrowCompositeType = "swap_fee_escrow"
+)
+
+// SwapFee is the fee paid by the creator of the swap in the swapped token, it's split among recipients
+type SwapFee struct {
+	Legs []*SwapFeeLeg
+}
+
+// SwapFeeLeg is the part of the swap fee paid to the address
+type SwapFeeLeg struct {
+	Address *types.Address
+	Amount  *big.Int
+}
+
+// SwapFeeCalculator is implemented by contracts which charge a fee for beginning a swap
+// of the amount of the token, nil fee means the swap is free for the payer
+type SwapFeeCalculator interface {
+	SwapFee(payer *types.Address, token string, amount *big.Int) (*SwapFee, error)
+}
+
+// swapFeeCharge charges the fee for the swap of amount from the same balance as the amount and keeps it
+// in the escrow of the swap till the swap is done, so the fee is refunded on cancel
+func (bc *BaseContract) swapFeeCharge(payer *types.Address, s *proto.Swap, amount *big.Int) error {
+	if bc.swapFeeCalculator == nil {
+		return nil
+	}
+	fee, err := bc.swapFeeCalculator.SwapFee(payer, s.Token, amount)
+	if err != nil || fee == nil {
+		return err
+	}
+	balance, path := swapFeeBalance(s)
+	// the freeze covers the token of the contract only
+	if balance == StateKeyTokenBalance {
+		if err = bc.freezeCheck(payer, nil); err != nil {
+			return err
+		}
+	}
+	total := big.NewInt(0)
+	for _, leg := range fee.Legs {
+		if leg.Amount.Sign() == 0 {
+			continue
+		}
+		s.FeeLegs = append(s.FeeLegs, &proto.SwapFeeLeg{Address: leg.Address.Bytes(), Amount: leg.Amount.Bytes()})
+		total.Add(total, leg.Amount)
+	}
+	if total.Sign() == 0 {
+		return nil
+	}
+	if err = balanceSub(bc.stub, balance, payer, total, path...); err != nil {
+		return err
+	}
+	s.Fee = total.Bytes()
+	s.FeeCurrency = s.Token
+	return swapFeeEscrowSet(bc.stub, s.Id, total)
+}
+
+// swapFeeRefund returns the fee kept in the escrow of the swap to its creator.
+// Freeze and transfer rules aren't checked as the fee never left the creator for good.
+func swapFeeRefund(stub shim.ChaincodeStubInterface, s *proto.Swap) error {
+	if len(s.FeeLegs) == 0 {
+		return nil
+	}
+	if err := swapFeeEscrowRelease(stub, s); err != nil {
+		return err
+	}
+	balance, path := swapFeeBalance(s)
+	creator := types.AddrFromBytes(s.Creator)
+	return balanceAdd(stub, balance, creator, new(big.Int).SetBytes(s.Fee), path...)
+}
+
+// swapFeeRelease pays the fee kept in the escrow of the swap to its recipients when the swap is done
+func swapFeeRelease(stub shim.ChaincodeStubInterface, s *proto.Swap) error {
+	if len(s.FeeLegs) == 0 {
+		return nil
+	}
+	if err := swapFeeEscrowRelease(stub, s); err != nil {
+		return err
+	}
+	balance, path := swapFeeBalance(s)
+	creator := types.AddrFromBytes(s.Creator)
+	for _, leg := range s.FeeLegs {
+		address, amount := types.AddrFromBytes(leg.Address), new(big.Int).SetBytes(leg.Amount)
+		if txStub, ok := stub.(*batchTxStub); ok {
+			txStub.AddAccountingRecord(s.FeeCurrency, creator, address, amount, SwapFeeReason)
+		}
+		if err := balanceAdd(stub, balance, address, amount, path...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// swapFeeBalance returns the state key and the path of the balance the swapped token is paid from,
+// the fee is refunded and released on the side where the swap begins, so this contract is swap.From
+func swapFeeBalance(s *proto.Swap) (StateKey, []string) {
+	if s.TokenSymbol() != s.From {
+		return StateKeyAllowedBalance, []string{s.Token}
+	}
+	if parts := strings.Split(s.Token, "_"); len(parts) > 1 {
+		return StateKeyTokenBalance, []string{parts[len(parts)-1]}
+	}
+	return StateKeyTokenBalance, nil
+}
+
+func swapFeeEscrowKey(stub shim.ChaincodeStubInterface, swapID []byte) (string, error) {
+	return stub.CreateCompositeKey(SwapFeeEscrowCompositeType, []string{hex.EncodeToString(swapID)})
+}
+
+func swapFeeEscrowSet(stub shim.ChaincodeStubInterface, swapID []byte, amount *big.Int) error {
+	key, err := swapFeeEscrowKey(stub, swapID)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, amount.Bytes())
+}
+
+// swapFeeEscrowRelease empties the escrow of the swap, it has to hold the whole fee of the swap
+func swapFeeEscrowRelease(stub shim.ChaincodeStubInterface, s *proto.Swap) error {
+	key, err := swapFeeEscrowKey(stub, s.Id)
+	if err != nil {
+		return err
+	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return err
+	}
+	if new(big.Int).SetBytes(data).Cmp(new(big.Int).SetBytes(s.Fee)) != 0 {
+		return errors.New("swap fee escrow doesn't match the fee")
+	}
+	return stub.DelState(key)
+}
@@ -0,0 +1,266 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+// Secondary indexes of open swaps and multiswaps, values are empty
+const (
+	SwapOwnerIndex        = "swap_owner"
+	SwapChannelIndex      = "swap_channel"
+	MultiSwapOwnerIndex   = "multi_swap_owner"
+	MultiSwapChannelIndex = "multi_swap_channel"
+	SwapExpiryIndex       = "swap_expiry"
+	MultiSwapExpiryIndex  = "multi_swap_expiry"
+
+	maxSwapPageSize = 100
+)
+
+var indexValue = []byte{0}
+
+// SwapInfo is an open swap with seconds left until it can be cancelled
+type SwapInfo struct {
+	Swap             *proto.Swap `json:"swap"`
+	RemainingTimeout int64       `json:"remainingTimeout"`
+}
+
+// SwapPage is a page of open swaps, Bookmark is empty on the last page
+type SwapPage struct {
+	Swaps    []SwapInfo `json:"swaps"`
+	Bookmark string     `json:"bookmark"`
+}
+
+// MultiSwapInfo is an open multiswap with seconds left until it can be cancelled
+type MultiSwapInfo struct {
+	MultiSwap        *proto.MultiSwap `json:"multiSwap"`
+	RemainingTimeout int64            `json:"remainingTimeout"`
+}
+
+// MultiSwapPage is a page of open multiswaps, Bookmark is empty on the last page
+type MultiSwapPage struct {
+	MultiSwaps []MultiSwapInfo `json:"multiSwaps"`
+	Bookmark   string          `json:"bookmark"`
+}
+
+// swapIndexEntry is the data needed to maintain indexes of an open swap or multiswap
+type swapIndexEntry struct {
+	id          string
+	multi       bool
+	owner       []byte
+	counterpart string
+	timeout     int64
+}
+
+func newSwapIndexEntry(s *proto.Swap) swapIndexEntry {
+	counterpart := swapCounterpart(s.Creator, s.From, s.To)
+	if s.ForwardedFrom != "" {
+		// forwarded swap goes on to the next hop of the route
+		counterpart = s.To
+	}
+	return swapIndexEntry{
+		id:          hex.EncodeToString(s.Id),
+		owner:       swapIndexOwner(s.Creator, s.Owner),
+		counterpart: counterpart,
+		timeout:     s.Timeout,
+	}
+}
+
+func newMultiSwapIndexEntry(s *proto.MultiSwap) swapIndexEntry {
+	return swapIndexEntry{
+		id:          hex.EncodeToString(s.Id),
+		multi:       true,
+		owner:       swapIndexOwner(s.Creator, s.Owner),
+		counterpart: swapCounterpart(s.Creator, s.From, s.To),
+		timeout:     s.Timeout,
+	}
+}
+
+// swapCounterpart returns the channel on the other side of the swap. Swaps created
+// by the robot (answered swaps) come from swap.From, others go to swap.To.
+func swapCounterpart(creator []byte, from string, to string) string {
+	if swapAnswered(creator) {
+		return from
+	}
+	return to
+}
+
+// swapIndexOwner returns the address whose funds are held by the swap: the sender
+// on the side where the swap begins and the recipient on the answer side
+func swapIndexOwner(creator []byte, owner []byte) []byte {
+	if swapAnswered(creator) {
+		return owner
+	}
+	return creator
+}
+
+func swapIndexAdd(stub shim.ChaincodeStubInterface, entry swapIndexEntry) error {
+	keys, err := swapIndexKeys(stub, entry)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err = stub.PutState(key, indexValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func swapIndexDel(stub shim.ChaincodeStubInterface, entry swapIndexEntry) error {
+	keys, err := swapIndexKeys(stub, entry)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err = stub.DelState(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func swapIndexKeys(stub shim.ChaincodeStubInterface, entry swapIndexEntry) ([]string, error) {
+	ownerIndex, channelIndex, expiryIndex := SwapOwnerIndex, SwapChannelIndex, SwapExpiryIndex
+	if entry.multi {
+		ownerIndex, channelIndex, expiryIndex = MultiSwapOwnerIndex, MultiSwapChannelIndex, MultiSwapExpiryIndex
+	}
+	ownerKey, err := stub.CreateCompositeKey(ownerIndex, []string{types.AddrFromBytes(entry.owner).String(), entry.id})
+	if err != nil {
+		return nil, err
+	}
+	channelKey, err := stub.CreateCompositeKey(channelIndex, []string{entry.counterpart, entry.id})
+	if err != nil {
+		return nil, err
+	}
+	expiryKey, err := stub.CreateCompositeKey(expiryIndex, []string{swapExpiryAttr(entry.timeout), entry.id})
+	if err != nil {
+		return nil, err
+	}
+	return []string{ownerKey, channelKey, expiryKey}, nil
+}
+
+// swapExpiryAttr pads the timeout so that keys of the expiry index are sorted by time
+func swapExpiryAttr(timeout int64) string {
+	return fmt.Sprintf("%020d", timeout)
+}
+
+// swapIndexPage returns swap IDs from the index starting with bookmark and the bookmark of the next page
+func swapIndexPage(
+	stub shim.ChaincodeStubInterface,
+	index string,
+	attr string,
+	pageSize int,
+	bookmark string,
+) ([]string, string, error) {
+	if pageSize <= 0 || pageSize > maxSwapPageSize {
+		return nil, "", errors.New("incorrect page size")
+	}
+	iter, err := stub.GetStateByPartialCompositeKey(index, []string{attr})
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var ids []string
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, "", err
+		}
+		_, keyParts, err := stub.SplitCompositeKey(kv.Key)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(keyParts) < 2 { //nolint:gomnd
+			return nil, "", errors.New("incorrect swap index key")
+		}
+		id := keyParts[1]
+		if id < bookmark {
+			continue
+		}
+		if len(ids) == pageSize {
+			return ids, id, nil
+		}
+		ids = append(ids, id)
+	}
+	return ids, "", nil
+}
+
+func remainingTimeout(stub shim.ChaincodeStubInterface, timeout int64) (int64, error) {
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return 0, err
+	}
+	if timeout <= ts.Seconds {
+		return 0, nil
+	}
+	return timeout - ts.Seconds, nil
+}
+
+func (bc *BaseContract) swapPage(index string, attr string, pageSize int, bookmark string) (*SwapPage, error) {
+	ids, next, err := swapIndexPage(bc.GetStub(), index, attr, pageSize, bookmark)
+	if err != nil {
+		return nil, err
+	}
+	page := &SwapPage{Swaps: []SwapInfo{}, Bookmark: next}
+	for _, id := range ids {
+		s, err := SwapLoad(bc.GetStub(), id)
+		if err != nil {
+			return nil, err
+		}
+		remaining, err := remainingTimeout(bc.GetStub(), s.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		page.Swaps = append(page.Swaps, SwapInfo{Swap: s, RemainingTimeout: remaining})
+	}
+	return page, nil
+}
+
+func (bc *BaseContract) multiSwapPage(index string, attr string, pageSize int, bookmark string) (*MultiSwapPage, error) {
+	ids, next, err := swapIndexPage(bc.GetStub(), index, attr, pageSize, bookmark)
+	if err != nil {
+		return nil, err
+	}
+	page := &MultiSwapPage{MultiSwaps: []MultiSwapInfo{}, Bookmark: next}
+	for _, id := range ids {
+		s, err := MultiSwapLoad(bc.GetStub(), id)
+		if err != nil {
+			return nil, err
+		}
+		remaining, err := remainingTimeout(bc.GetStub(), s.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		page.MultiSwaps = append(page.MultiSwaps, MultiSwapInfo{MultiSwap: s, RemainingTimeout: remaining})
+	}
+	return page, nil
+}
+
+// QuerySwapsByOwner returns open swaps of the owner
+func (bc *BaseContract) QuerySwapsByOwner(owner *types.Address, pageSize int, bookmark string) (*SwapPage, error) {
+	return bc.swapPage(SwapOwnerIndex, owner.String(), pageSize, bookmark)
+}
+
+// QuerySwapsByChannel returns open swaps with the counterpart channel
+func (bc *BaseContract) QuerySwapsByChannel(channel string, pageSize int, bookmark string) (*SwapPage, error) {
+	return bc.swapPage(SwapChannelIndex, channel, pageSize, bookmark)
+}
+
+// QueryMultiSwapsByOwner returns open multiswaps of the owner
+func (bc *BaseContract) QueryMultiSwapsByOwner(owner *types.Address, pageSize int, bookmark string) (*MultiSwapPage, error) {
+	return bc.multiSwapPage(MultiSwapOwnerIndex, owner.String(), pageSize, bookmark)
+}
+
+// QueryMultiSwapsByChannel returns open multiswaps with the counterpart channel
+func (bc *BaseContract) QueryMultiSwapsByChannel(channel string, pageSize int, bookmark string) (*MultiSwapPage, error) {
+	return bc.multiSwapPage(MultiSwapChannelIndex, channel, pageSize, bookmark)
+}
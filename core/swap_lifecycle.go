@@ -0,0 +1,150 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const SwapLifecycleCompositeType = "swap_lifecycle"
+
+// Swap lifecycle statuses
+const (
+	SwapStatusCreated   = "created"
+	SwapStatusAnswered  = "answered"
+	SwapStatusRobotDone = "robotDone"
+	SwapStatusUserDone  = "userDone"
+	SwapStatusCancelled = "cancelled"
+)
+
+// swapConfig holds swap timeouts in seconds, zero values mean defaults
+type swapConfig struct {
+	userSideTimeout    int64
+	robotSideTimeout   int64
+	minUserSideTimeout int64
+	maxUserSideTimeout int64
+}
+
+// newSwapConfig checks that the swap can't be canceled by the user before the robot side
+// of the swap expires, otherwise the user could both cancel the swap and take its answer
+func newSwapConfig(options *ContractOptions) (swapConfig, error) {
+	if options == nil {
+		return swapConfig{}, nil
+	}
+	c := swapConfig{
+		userSideTimeout:    int64(options.SwapUserSideTimeout),
+		robotSideTimeout:   int64(options.SwapRobotSideTimeout),
+		minUserSideTimeout: int64(options.SwapMinUserSideTimeout),
+		maxUserSideTimeout: int64(options.SwapMaxUserSideTimeout),
+	}
+	minUserSide := c.robotSide() + swapSettleMargin
+	if c.userSide() <= minUserSide {
+		return c, fmt.Errorf("swap user side timeout should be greater than %d", minUserSide)
+	}
+	if c.maxUserSideTimeout == 0 {
+		return c, nil
+	}
+	if c.minUserSideTimeout <= minUserSide {
+		return c, fmt.Errorf("swap min user side timeout should be greater than %d", minUserSide)
+	}
+	if c.maxUserSideTimeout < c.minUserSideTimeout {
+		return c, errors.New("swap max user side timeout is less than min")
+	}
+	return c, nil
+}
+
+func (c swapConfig) userSide() int64 {
+	if c.userSideTimeout == 0 {
+		return userSideTimeout
+	}
+	return c.userSideTimeout
+}
+
+func (c swapConfig) robotSide() int64 {
+	if c.robotSideTimeout == 0 {
+		return robotSideTimeout
+	}
+	return c.robotSideTimeout
+}
+
+// checkUserSide checks the timeout requested for a single swap
+func (c swapConfig) checkUserSide(timeout int64) error {
+	if c.maxUserSideTimeout == 0 {
+		return errors.New("custom swap timeout is not allowed")
+	}
+	if timeout < c.minUserSideTimeout || timeout > c.maxUserSideTimeout {
+		return errors.New("swap timeout is out of bounds")
+	}
+	return nil
+}
+
+// swapLifecycleSet saves the time of the swap stage, the record is kept after the swap is deleted
+func swapLifecycleSet(stub shim.ChaincodeStubInterface, swapID []byte, multi bool, status string, timeout int64) error {
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	key, err := stub.CreateCompositeKey(SwapLifecycleCompositeType, []string{hex.EncodeToString(swapID)})
+	if err != nil {
+		return err
+	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return err
+	}
+	l := &proto.SwapLifecycle{Id: swapID, Multi: multi}
+	if len(data) > 0 {
+		if err = pb.Unmarshal(data, l); err != nil {
+			return err
+		}
+	}
+
+	l.Status = status
+	switch status {
+	case SwapStatusCreated:
+		l.Created = ts.Seconds
+		l.Timeout = timeout
+	case SwapStatusAnswered:
+		l.Answered = ts.Seconds
+		l.Timeout = timeout
+	case SwapStatusRobotDone:
+		l.RobotDone = ts.Seconds
+	case SwapStatusUserDone:
+		l.UserDone = ts.Seconds
+	case SwapStatusCancelled:
+		l.Cancelled = ts.Seconds
+	}
+
+	if data, err = pb.Marshal(l); err != nil {
+		return err
+	}
+	return stub.PutState(key, data)
+}
+
+func SwapLifecycleLoad(stub shim.ChaincodeStubInterface, swapID string) (*proto.SwapLifecycle, error) {
+	key, err := stub.CreateCompositeKey(SwapLifecycleCompositeType, []string{swapID})
+	if err != nil {
+		return nil, err
+	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("swap lifecycle doesn't exist")
+	}
+	var l proto.SwapLifecycle
+	if err = pb.Unmarshal(data, &l); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// QuerySwapLifecycle returns stages of the swap or multiswap including completed ones
+func (bc *BaseContract) QuerySwapLifecycle(swapID string) (*proto.SwapLifecycle, error) {
+	return SwapLifecycleLoad(bc.GetStub(), swapID)
+}
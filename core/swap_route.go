@@ -0,0 +1,72 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const swapRouteSeparator = ","
+
+// TxSwapBeginRoute begins swap through the comma separated route of contracts under one hash.
+// Every hop is answered by the robot and forwarded to the next contract, the user reveals
+// the key on the last contract and the robot settles all hops with it.
+func (bc *BaseContract) TxSwapBeginRoute(
+	sender *types.Sender,
+	token string,
+	route string,
+	amount *big.Int,
+	hash types.Hex,
+) (string, error) {
+	hops := strings.Split(route, swapRouteSeparator)
+	if err := swapRouteCheck(token, append([]string{bc.id}, hops...)); err != nil {
+		return "", err
+	}
+	return bc.swapBegin(sender, token, hops[0], amount, hash, sender.Address(), bc.swapConfig.userSide(), hops[1:])
+}
+
+// swapRouteCheck checks that the route has more than one hop, contracts aren't repeated
+// and the token belongs to one of the contracts of every hop
+func swapRouteCheck(token string, contracts []string) error {
+	if len(contracts) < 3 { //nolint:gomnd
+		return errors.New("route should have at least two hops")
+	}
+	symbol := strings.Split(token, "_")[0]
+	seen := make(map[string]struct{}, len(contracts))
+	for i, contract := range contracts {
+		if _, ok := seen[contract]; ok || contract == "" {
+			return fmt.Errorf("incorrect contract %s in route", contract)
+		}
+		seen[contract] = struct{}{}
+		if i > 0 && symbol != contracts[i-1] && symbol != contract {
+			return errors.New(ErrIncorrectSwap)
+		}
+	}
+	return nil
+}
+
+// swapForward turns the answered swap into the next hop of its route. The value which came
+// from swap.From goes on to the next contract, so only the contract of the token can forward.
+// The forwarded swap lives longer than answers of the following hops and ends the settle margin
+// before the swap it answers, the following hops are shortened by the margin the same way.
+func swapForward(stub shim.ChaincodeStubInterface, swap *proto.Swap, now int64, timeout int64, prev int64) error {
+	if swap.TokenSymbol() != swap.To {
+		return errors.New(ErrIncorrectSwap)
+	}
+	next := swap.Route[0]
+	if err := givenBalanceReserve(stub, next, new(big.Int).SetBytes(swap.Amount)); err != nil {
+		return err
+	}
+	forwardTimeout, err := answerTimeout(now, timeout*int64(len(swap.Route)+1), prev)
+	if err != nil {
+		return err
+	}
+	swap.Timeout = forwardTimeout
+	swap.ForwardedFrom, swap.From, swap.To, swap.Route = swap.From, swap.To, next, swap.Route[1:]
+	return nil
+}
@@ -0,0 +1,271 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"runtime/debug"
+	"strconv"
+
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const (
+	sweepExpiredSwapsMethod = "sweepExpiredSwaps"
+	maxSweepSwaps           = 1000
+)
+
+// sweepExecute refunds expired swaps and multiswaps outside of the batch,
+// it can be called by the robot or the atomyze admin
+func (cc *ChainCode) sweepExecute(stub shim.ChaincodeStubInterface, creatorSKI string, limitArg string) peer.Response {
+	limit, err := strconv.Atoi(limitArg)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if limit <= 0 || limit > maxSweepSwaps {
+		return shim.Error("incorrect limit of swaps")
+	}
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	btchStub := newBatchStub(stub)
+	btchStub.log = cc.log.With(LogFields{LogFieldChannel: stub.GetChannelID(), LogFieldTxID: stub.GetTxID()})
+	responses, holds, event := cc.sweepExpiredSwaps(btchStub, creatorSKI, limit, ts.Seconds)
+	if err = btchStub.Commit(); err != nil {
+		return shim.Error(err.Error())
+	}
+
+	data, err := pb.Marshal(&proto.BatchResponse{SweptSwapResponses: responses, SweptHoldResponses: holds})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	eventData, err := pb.Marshal(&proto.BatchEvent{Events: []*proto.BatchTxEvent{event}})
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+	if err = stub.SetEvent(sweepExpiredSwapsMethod, eventData); err != nil {
+		return shim.Error(err.Error())
+	}
+	return shim.Success(data)
+}
+
+// sweepExpiredSwaps refunds up to limit swaps and multiswaps which timeout has passed
+// the same way as cancel does. Accounting records and events of all refunds are returned in one event.
+// The rest of the limit is used to release expired holds, see sweepExpiredHolds.
+func (cc *ChainCode) sweepExpiredSwaps(
+	stub *batchStub,
+	creatorSKI string,
+	limit int,
+	now int64,
+) ([]*proto.SwapResponse, []*proto.HoldResponse, *proto.BatchTxEvent) {
+	id, _ := hex.DecodeString(stub.GetTxID())
+	event := &proto.BatchTxEvent{Id: id, Method: sweepExpiredSwapsMethod}
+
+	var responses []*proto.SwapResponse
+	for _, multi := range []bool{false, true} {
+		if (!multi && cc.disableSwaps) || (multi && cc.disableMultiSwaps) {
+			continue
+		}
+		index := SwapExpiryIndex
+		if multi {
+			index = MultiSwapExpiryIndex
+		}
+		swapIDs, err := expiredSwapIDs(stub, index, now, limit-len(responses))
+		if err != nil {
+			stub.log.Errorf("Couldn't get expired swaps: %s", err.Error())
+			event.Error = &proto.ResponseError{Error: err.Error()}
+			return responses, nil, event
+		}
+		for _, swapID := range swapIDs {
+			resp, accounting := cc.sweepSwap(stub, creatorSKI, swapID, multi)
+			responses = append(responses, resp)
+			event.Accounting = append(event.Accounting, accounting...)
+			event.Events = append(event.Events, resp.Events...)
+		}
+	}
+
+	holds, err := cc.sweepExpiredHolds(stub, creatorSKI, limit-len(responses), now)
+	if err != nil {
+		stub.log.Errorf("Couldn't get expired holds: %s", err.Error())
+		event.Error = &proto.ResponseError{Error: err.Error()}
+	}
+	for _, resp := range holds {
+		event.Events = append(event.Events, resp.Events...)
+	}
+	return responses, holds, event
+}
+
+// sweepExpiredHolds releases up to limit holds which expiry has passed, every hold gets its own response.
+// A hold which can't be released is dropped from the expiry index, so it doesn't take the limit
+// of next sweeps, it's still released by the owner's operations or by anyone with holdRelease.
+func (cc *ChainCode) sweepExpiredHolds(
+	stub *batchStub,
+	creatorSKI string,
+	limit int,
+	now int64,
+) ([]*proto.HoldResponse, error) {
+	holds, err := expiredHolds(stub, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]*proto.HoldResponse, 0, len(holds))
+	for _, h := range holds {
+		resp := cc.sweepHold(stub, creatorSKI, h)
+		if resp.Error != nil {
+			stub.log.Errorf("Couldn't release expired hold %s: %s", h.Id, resp.Error.Error)
+			if err = holdExpiryDel(stub, h); err != nil {
+				return responses, err
+			}
+		}
+		responses = append(responses, resp)
+	}
+	return responses, nil
+}
+
+// sweepHold releases the expired hold in its own transaction stub, so a failed release doesn't affect others
+func (cc *ChainCode) sweepHold(stub *batchStub, creatorSKI string, h *proto.Hold) (r *proto.HoldResponse) {
+	r = &proto.HoldResponse{Id: h.Id, Owner: h.Owner, Error: &proto.ResponseError{Error: "panic sweepHold"}}
+	defer func() {
+		if rc := recover(); rc != nil {
+			stub.log.Criticalf("panic sweepHold: %s\n%s", h.Id, string(debug.Stack()))
+		}
+	}()
+
+	txStub := stub.newTxStub(stub.GetTxID(), creatorSKI)
+	_, contract := copyContract(cc.contract, txStub, cc.allowedMspID, cc.init.AtomyzeSKI, cc.init.Args, cc.noncePrefix)
+	if err := contract.holdRelease(h); err != nil {
+		return &proto.HoldResponse{Id: h.Id, Owner: h.Owner, Error: &proto.ResponseError{Error: err.Error()}}
+	}
+	writes, events := txStub.Commit()
+	return &proto.HoldResponse{Id: h.Id, Owner: h.Owner, Writes: writes, Events: events}
+}
+
+// expiredHolds returns up to limit holds from the expiry index which expiry is not after now
+func expiredHolds(stub shim.ChaincodeStubInterface, now int64, limit int) ([]*proto.Hold, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	iter, err := stub.GetStateByPartialCompositeKey(HoldExpiryIndex, []string{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var holds []*proto.Hold
+	for iter.HasNext() && len(holds) < limit {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		_, keyParts, err := stub.SplitCompositeKey(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		if len(keyParts) < 3 { //nolint:gomnd
+			return nil, errors.New("incorrect hold expiry key")
+		}
+		if keyParts[0] > swapExpiryAttr(now) {
+			break
+		}
+		owner, err := types.AddrFromBase58Check(keyParts[1])
+		if err != nil {
+			return nil, err
+		}
+		// the hold may be already released in the current batch
+		h, err := HoldLoad(stub, owner, keyParts[2])
+		if err != nil {
+			if err.Error() == ErrHoldNotFound {
+				continue
+			}
+			return nil, err
+		}
+		holds = append(holds, h)
+	}
+	return holds, nil
+}
+
+func (cc *ChainCode) sweepSwap(
+	stub *batchStub,
+	creatorSKI string,
+	swapID string,
+	multi bool,
+) (r *proto.SwapResponse, accounting []*proto.AccountingRecord) {
+	id, _ := hex.DecodeString(swapID)
+	r = &proto.SwapResponse{Id: id, Error: &proto.ResponseError{Error: "panic sweepSwap"}}
+	defer func() {
+		if rc := recover(); rc != nil {
+			stub.log.Criticalf("panic sweepSwap: %s\n%s", swapID, string(debug.Stack()))
+		}
+	}()
+
+	txStub := stub.newTxStub(swapID, creatorSKI)
+	_, contract := copyContract(cc.contract, txStub, cc.allowedMspID, cc.init.AtomyzeSKI, cc.init.Args, cc.noncePrefix)
+
+	var err error
+	if multi {
+		var swap *proto.MultiSwap
+		if swap, err = MultiSwapLoad(txStub, swapID); err == nil {
+			err = multiSwapRefund(contract, swap)
+		}
+	} else {
+		var swap *proto.Swap
+		if swap, err = SwapLoad(txStub, swapID); err == nil {
+			err = swapRefund(contract, swap)
+		}
+	}
+	if err != nil {
+		return &proto.SwapResponse{Id: id, Error: &proto.ResponseError{Error: err.Error()}}, nil
+	}
+
+	writes, events := txStub.Commit()
+	return &proto.SwapResponse{Id: id, Writes: writes, Events: events}, txStub.accounting
+}
+
+// expiredSwapIDs returns IDs from the expiry index which timeout is not after now
+func expiredSwapIDs(stub shim.ChaincodeStubInterface, index string, now int64, limit int) ([]string, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	iter, err := stub.GetStateByPartialCompositeKey(index, []string{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var ids []string
+	for iter.HasNext() && len(ids) < limit {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		_, keyParts, err := stub.SplitCompositeKey(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		if len(keyParts) < 2 { //nolint:gomnd
+			return nil, errors.New("incorrect swap expiry key")
+		}
+		if keyParts[0] > swapExpiryAttr(now) {
+			break
+		}
+		// the swap may be already completed in the current batch
+		value, err := stub.GetState(kv.Key)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		ids = append(ids, keyParts[1])
+	}
+	return ids, nil
+}
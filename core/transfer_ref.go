@@ -0,0 +1,85 @@
+package core
+
+import (
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const TransferRefCompositeType = "transfer_ref"
+
+// WithTransferRef runs the transfer made by the sender and saves it by the ref and the transaction,
+// the ref is put into the accounting records of the transfer and into the batch event.
+// The same ref can be used by the sender many times, empty ref is not saved.
+func (bc *BaseContract) WithTransferRef(sender *types.Address, ref string, transfer func() error) error {
+	if ref == "" {
+		return transfer()
+	}
+	txStub, isBatch := bc.stub.(*batchTxStub)
+	start := 0
+	if isBatch {
+		start = len(txStub.accounting)
+	}
+	if err := transfer(); err != nil {
+		return err
+	}
+
+	key, err := bc.stub.CreateCompositeKey(TransferRefCompositeType, []string{sender.String(), ref, bc.stub.GetTxID()})
+	if err != nil {
+		return err
+	}
+	// the ref can be used more than once in a multicall
+	transferRef := &proto.TransferRef{}
+	data, err := bc.stub.GetState(key)
+	if err != nil {
+		return err
+	}
+	if data != nil {
+		if err = pb.Unmarshal(data, transferRef); err != nil {
+			return err
+		}
+	} else {
+		ts, err := bc.stub.GetTxTimestamp()
+		if err != nil {
+			return err
+		}
+		transferRef.TxId = bc.stub.GetTxID()
+		transferRef.Timestamp = ts.Seconds
+	}
+	if isBatch {
+		for _, record := range txStub.accounting[start:] {
+			record.Ref = ref
+		}
+		transferRef.Accounting = append(transferRef.Accounting, txStub.accounting[start:]...)
+		txStub.refs = append(txStub.refs, ref)
+	}
+	if data, err = pb.Marshal(transferRef); err != nil {
+		return err
+	}
+	return bc.stub.PutState(key, data)
+}
+
+// QueryTransfersByRef returns transfers made by the sender with the ref ordered by transaction ID
+func (bc *BaseContract) QueryTransfersByRef(sender *types.Address, ref string) ([]*proto.TransferRef, error) {
+	iter, err := bc.stub.GetStateByPartialCompositeKey(TransferRefCompositeType, []string{sender.String(), ref})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	result := make([]*proto.TransferRef, 0)
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		transferRef := &proto.TransferRef{}
+		if err = pb.Unmarshal(kv.Value, transferRef); err != nil {
+			return nil, err
+		}
+		result = append(result, transferRef)
+	}
+	return result, nil
+}
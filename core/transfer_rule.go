@@ -0,0 +1,230 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/helpers"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+)
+
+// reason codes of QueryCanTransfer
+const (
+	TransferCodeOK                  = "OK"
+	TransferCodeFrozen              = "FROZEN"
+	TransferCodeInsufficientBalance = "INSUFFICIENT_BALANCE"
+	TransferCodeMaxHolders          = "MAX_HOLDERS"
+	TransferCodeMaxBalance          = "MAX_BALANCE"
+	TransferCodeJurisdiction        = "JURISDICTION"
+	TransferCodeLockUp              = "LOCK_UP"
+)
+
+// TransferRestrictedError is returned by the rule which doesn't allow the transfer
+type TransferRestrictedError struct {
+	Code    string
+	Message string
+}
+
+func (e *TransferRestrictedError) Error() string {
+	return e.Message
+}
+
+// TransferRule checks the transfer before balances are changed
+type TransferRule interface {
+	CheckTransfer(tr *TransferRequest) error
+}
+
+// TransferRules is implemented by contracts which restrict transfers of the token and allowed tokens.
+// Rules are checked whenever the token or an allowed token is credited to the address: transfers,
+// transfers of locked balances (holds and DvP), emission and done swaps. From is nil if the credit
+// has no sender. Industrial tokens and refunds of swaps and swap fees aren't checked.
+// Rules see holdings with locked and vesting balances, so locks, unlocks and claims of vested funds
+// don't change them and need no check.
+type TransferRules interface {
+	TransferRules() []TransferRule
+}
+
+// TransferRequest is the transfer checked by rules. Allowed is set for transfers of allowed tokens,
+// From is nil for credits without a sender such as emission.
+type TransferRequest struct {
+	Stub    shim.ChaincodeStubInterface
+	Token   string
+	Allowed bool
+	From    *types.Address
+	To      *types.Address
+	Amount  *big.Int
+}
+
+// Balance returns the holding of the address in the token of the transfer: free and locked balances
+// and the vesting balance of the token of the contract, so locking funds doesn't bypass rules
+func (tr *TransferRequest) Balance(address *types.Address) (*big.Int, error) {
+	balances, path := tr.holdingBalances()
+	return holding(tr.Stub, balances, address, path)
+}
+
+// Holders returns the number of addresses with positive holding of the token of the transfer,
+// the number is kept in the state and updated whenever balances of the token are changed
+func (tr *TransferRequest) Holders() (int, error) {
+	balances, path := tr.holdingBalances()
+	return holdersGet(tr.Stub, balances, path)
+}
+
+func (tr *TransferRequest) holdingBalances() ([]StateKey, []string) {
+	if tr.Allowed {
+		return holdingBalances(StateKeyAllowedBalance, []string{tr.Token}), []string{tr.Token}
+	}
+	return holdingBalances(StateKeyTokenBalance, nil), nil
+}
+
+// checkTransferRules checks rules of the contract for the transfer
+func (bc *BaseContract) checkTransferRules(token string, from *types.Address, to *types.Address, amount *big.Int) error {
+	if bc.transferRules == nil {
+		return nil
+	}
+	tr := &TransferRequest{
+		Stub:    bc.stub,
+		Token:   token,
+		Allowed: token != bc.id,
+		From:    from,
+		To:      to,
+		Amount:  amount,
+	}
+	for _, rule := range bc.transferRules.TransferRules() {
+		if err := rule.CheckTransfer(tr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CanTransfer is the result of QueryCanTransfer
+type CanTransfer struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// QueryCanTransfer checks whether the amount of the token can be transferred
+// and returns the reason code if it can't
+func (bc *BaseContract) QueryCanTransfer(from *types.Address, to *types.Address, amount *big.Int) (*CanTransfer, error) {
+	if err := bc.freezeCheck(from, to); err != nil {
+		return &CanTransfer{Code: TransferCodeFrozen, Message: err.Error()}, nil
+	}
+	balance, err := bc.TokenBalanceGet(from)
+	if err != nil {
+		return nil, err
+	}
+	if balance.Cmp(amount) < 0 {
+		return &CanTransfer{Code: TransferCodeInsufficientBalance, Message: "insufficient funds to process"}, nil
+	}
+	if err = bc.checkTransferRules(bc.id, from, to, amount); err != nil {
+		if restricted, ok := err.(*TransferRestrictedError); ok { //nolint:errorlint
+			return &CanTransfer{Code: restricted.Code, Message: restricted.Message}, nil
+		}
+		return nil, err
+	}
+	return &CanTransfer{Code: TransferCodeOK}, nil
+}
+
+// MaxHoldersRule limits the number of holders of the token
+type MaxHoldersRule struct {
+	Max int
+}
+
+func (r *MaxHoldersRule) CheckTransfer(tr *TransferRequest) error {
+	balance, err := tr.Balance(tr.To)
+	if err != nil || balance.Sign() > 0 {
+		return err
+	}
+	// the sender remains the holder if the sender doesn't transfer the whole balance
+	if tr.From != nil {
+		balance, err = tr.Balance(tr.From)
+		if err != nil || balance.Cmp(tr.Amount) <= 0 {
+			return err
+		}
+	}
+	holders, err := tr.Holders()
+	if err != nil {
+		return err
+	}
+	if holders+1 > r.Max {
+		return &TransferRestrictedError{
+			Code:    TransferCodeMaxHolders,
+			Message: fmt.Sprintf("number of holders can't exceed %d", r.Max),
+		}
+	}
+	return nil
+}
+
+// MaxBalanceRule limits the balance of the token of one holder
+type MaxBalanceRule struct {
+	Max *big.Int
+}
+
+func (r *MaxBalanceRule) CheckTransfer(tr *TransferRequest) error {
+	balance, err := tr.Balance(tr.To)
+	if err != nil {
+		return err
+	}
+	if new(big.Int).Add(balance, tr.Amount).Cmp(r.Max) > 0 {
+		return &TransferRestrictedError{
+			Code:    TransferCodeMaxBalance,
+			Message: fmt.Sprintf("balance of %s can't exceed %s", tr.To.String(), r.Max.String()),
+		}
+	}
+	return nil
+}
+
+// JurisdictionRule allows transfers only to holders of allowed jurisdictions,
+// the jurisdiction is derived from the KYC hash of the account
+type JurisdictionRule struct {
+	Allowed      []string
+	Jurisdiction func(kycHash string) (string, error)
+}
+
+func (r *JurisdictionRule) CheckTransfer(tr *TransferRequest) error {
+	info, err := helpers.GetAccountInfo(tr.Stub, tr.To.String())
+	if err != nil {
+		return err
+	}
+	jurisdiction, err := r.Jurisdiction(info.KycHash)
+	if err != nil {
+		return err
+	}
+	if !contains(r.Allowed, jurisdiction) {
+		return &TransferRestrictedError{
+			Code:    TransferCodeJurisdiction,
+			Message: fmt.Sprintf("jurisdiction %s of %s isn't allowed", jurisdiction, tr.To.String()),
+		}
+	}
+	return nil
+}
+
+// LockUpRule forbids transfers until the time in unix seconds, except transfers from the exempt addresses
+// and credits without a sender
+type LockUpRule struct {
+	Until  int64
+	Exempt []*types.Address
+}
+
+func (r *LockUpRule) CheckTransfer(tr *TransferRequest) error {
+	if tr.From == nil {
+		return nil
+	}
+	for _, address := range r.Exempt {
+		if address.Equal(tr.From) {
+			return nil
+		}
+	}
+	ts, err := tr.Stub.GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	if ts.Seconds < r.Until {
+		return &TransferRestrictedError{
+			Code:    TransferCodeLockUp,
+			Message: fmt.Sprintf("token is locked up until %d", r.Until),
+		}
+	}
+	return nil
+}
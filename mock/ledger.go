@@ -65,6 +65,7 @@ type Ledger struct {
 	txResponseEvents    map[string]chan TxResponse
 	txResponseEventLock *sync.Mutex
 	batchPrefix         string
+	timeShift           time.Duration
 }
 
 func (ledger *Ledger) GetStubByKey(key string) *stub.Stub {
@@ -113,6 +114,7 @@ type TxResponse struct {
 	Result     string                    `json:"result"`
 	Events     map[string][]byte         `json:"events,omitempty"`
 	Accounting []*proto.AccountingRecord `json:"accounting"`
+	Refs       []string                  `json:"refs,omitempty"`
 }
 
 const batchRobotCertHash = "380499dcb3d3ee374ccfd74cbdcbe03a1cd5ae66b282e5673dcb13cbe290965b"
@@ -124,6 +126,7 @@ func (ledger *Ledger) NewChainCode(name string, bci core.BaseContractInterface,
 	assert.NoError(ledger.t, err)
 	ledger.stubs[name] = stub.NewMockStub(name, cc)
 	ledger.stubs[name].ChannelID = name
+	ledger.stubs[name].TimeShift = ledger.timeShift
 	ledger.stubs[name].MockPeerChaincode("acl/acl", ledger.stubs["acl"])
 	args := [][]byte{[]byte(""), []byte(batchRobotCertHash)}
 	for _, arg := range initArgs {
@@ -143,6 +146,7 @@ func (ledger *Ledger) NewChainCodeWithCustomMSP(name string, bci core.BaseContra
 	assert.NoError(ledger.t, err)
 	ledger.stubs[name] = stub.NewMockStub(name, cc)
 	ledger.stubs[name].ChannelID = name
+	ledger.stubs[name].TimeShift = ledger.timeShift
 	ledger.stubs[name].MockPeerChaincode("acl/acl", ledger.stubs["acl"])
 	args := [][]byte{[]byte(""), []byte(batchRobotCertHash)}
 	for _, arg := range initArgs {
@@ -161,6 +165,14 @@ func (ledger *Ledger) NewChainCodeWithCustomMSP(name string, bci core.BaseContra
 	return ""
 }
 
+// AddTime moves the time of next transactions of all channels forward
+func (ledger *Ledger) AddTime(d time.Duration) {
+	ledger.timeShift += d
+	for _, s := range ledger.stubs {
+		s.TimeShift = ledger.timeShift
+	}
+}
+
 func (ledger *Ledger) GetStub(name string) *stub.Stub {
 	return ledger.stubs[name]
 }
@@ -335,6 +347,7 @@ type Metadata struct {
 	Issuer          string          `json:"issuer"`
 	Methods         []string        `json:"methods"`
 	TotalEmission   *big.Int        `json:"total_emission"` //nolint:tagliatelle
+	MaxSupply       *big.Int        `json:"max_supply"`     //nolint:tagliatelle
 	Fee             *Fee            `json:"fee"`
 	Rates           []*MetadataRate `json:"rates"`
 }
@@ -372,11 +385,14 @@ type MetadataGroup struct {
 }
 
 type MetadataRate struct {
-	DealType string   `json:"deal_type"` //nolint:tagliatelle
-	Currency string   `json:"currency"`
-	Rate     *big.Int `json:"rate"`
-	Min      *big.Int `json:"min"`
-	Max      *big.Int `json:"max"`
+	DealType  string   `json:"deal_type"` //nolint:tagliatelle
+	Currency  string   `json:"currency"`
+	Rate      *big.Int `json:"rate"`
+	Min       *big.Int `json:"min"`
+	Max       *big.Int `json:"max"`
+	ValidFrom int64    `json:"valid_from,omitempty"` //nolint:tagliatelle
+	ValidTo   int64    `json:"valid_to,omitempty"`   //nolint:tagliatelle
+	Version   uint64   `json:"version,omitempty"`
 }
 
 func (ledger *Ledger) Metadata(ch string) *Metadata {
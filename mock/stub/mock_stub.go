@@ -13,6 +13,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/golang/protobuf/proto" //nolint:staticcheck
@@ -46,6 +47,7 @@ type Stub struct {
 	// TODO if a chaincode uses recursion this may need to be a stack of TxIDs or possibly a reference counting map
 	TxID                   string // stores a transaction uuid while being Invoked / Deployed
 	TxTimestamp            *timestamp.Timestamp
+	TimeShift              time.Duration      // added to the time of transactions to test timeouts
 	signedProposal         *pb.SignedProposal // mocked signedProposal
 	ChannelID              string             // stores a channel ID of the proposal
 	PvtState               map[string]map[string][]byte
@@ -93,7 +95,9 @@ func (stub *Stub) GetFunctionAndParameters() (function string, params []string)
 func (stub *Stub) MockTransactionStart(txID string) {
 	stub.TxID = txID
 	stub.setSignedProposal(&pb.SignedProposal{})
-	stub.setTxTimestamp(util.CreateUtcTimestamp())
+	ts := util.CreateUtcTimestamp()
+	ts.Seconds += int64(stub.TimeShift / time.Second)
+	stub.setTxTimestamp(ts)
 }
 
 // MockTransactionEnd ends a mocked transaction, clearing the UUID.
@@ -88,6 +88,11 @@ func (w *Wallet) addBalance(stub *stub.Stub, amount *big.Int, balanceType core.S
 	balance := new(big.Int).SetBytes(data)
 	newBalance := new(big.Int).Add(balance, amount)
 	_ = stub.PutBalanceToState(key, newBalance)
+
+	// the counter of holders is recounted from balances on the next change
+	holdersKey, err := stub.CreateCompositeKey(core.HoldersCompositeType, path)
+	assert.NoError(w.ledger.t, err)
+	delete(stub.State, holdersKey)
 }
 
 func (w *Wallet) CheckGivenBalanceShouldBe(ch string, token string, expectedBalance uint64) {
@@ -218,10 +223,12 @@ func (w *Wallet) BatchedInvoke(ch string, fn string, args ...string) (string, Tx
 					er = ev.Error.Error
 				}
 				return txID, TxResponse{
-					Method: ev.Method,
-					Error:  er,
-					Result: string(ev.Result),
-					Events: evts,
+					Method:     ev.Method,
+					Error:      er,
+					Result:     string(ev.Result),
+					Events:     evts,
+					Accounting: ev.Accounting,
+					Refs:       ev.Refs,
 				}
 			}
 		}
@@ -238,6 +245,15 @@ func (w *Wallet) sign(fn string, ch string, args ...string) ([]string, string) {
 	return append(result[1:], base58.Encode(ed25519.Sign(w.sKey, message[:]))), hex.EncodeToString(message[:])
 }
 
+// signWithDeadline signs args like sign does, but puts deadline (unix seconds) after the nonce
+func (w *Wallet) signWithDeadline(fn string, ch string, deadline int64, args ...string) []string {
+	time.Sleep(time.Millisecond * 5)                              //nolint:gomnd
+	nonce := strconv.FormatInt(time.Now().UnixNano()/1000000, 10) //nolint:gomnd
+	result := append(append([]string{fn, "", ch, ch}, args...), nonce, core.DeadlineArgPrefix+strconv.FormatInt(deadline, 10), base58.Encode(w.pKey))
+	message := sha3.Sum256([]byte(strings.Join(result, "")))
+	return append(result[1:], base58.Encode(ed25519.Sign(w.sKey, message[:])))
+}
+
 type BatchTxResponse map[string]*proto.TxResponse
 
 func (w *Wallet) DoBatch(ch string, txID ...string) BatchTxResponse {
@@ -266,6 +282,75 @@ func (w *Wallet) DoBatch(ch string, txID ...string) BatchTxResponse {
 	return result
 }
 
+// SwapRobotDone completes the swap on the channel where it began
+// with the key revealed by the user, like the robot does
+func (w *Wallet) SwapRobotDone(ch string, swapID string, key string) *proto.SwapResponse {
+	id, err := hex.DecodeString(swapID)
+	assert.NoError(w.ledger.t, err)
+	return w.robotDone(ch, &proto.Batch{Keys: []*proto.SwapKey{{Id: id, Key: key}}})
+}
+
+// MultiSwapRobotDone completes the multiswap on the channel where it began
+// with the key revealed by the user, like the robot does
+func (w *Wallet) MultiSwapRobotDone(ch string, swapID string, key string) *proto.SwapResponse {
+	id, err := hex.DecodeString(swapID)
+	assert.NoError(w.ledger.t, err)
+	return w.robotDone(ch, &proto.Batch{MultiSwapsKeys: []*proto.SwapKey{{Id: id, Key: key}}})
+}
+
+func (w *Wallet) robotDone(ch string, batch *proto.Batch) *proto.SwapResponse {
+	data, err := pb.Marshal(batch)
+	assert.NoError(w.ledger.t, err)
+
+	cert, err := hex.DecodeString(batchRobotCert)
+	assert.NoError(w.ledger.t, err)
+	w.ledger.stubs[ch].SetCreator(cert)
+	res := w.Invoke(ch, "batchExecute", string(data))
+	out := &proto.BatchResponse{}
+	assert.NoError(w.ledger.t, pb.Unmarshal([]byte(res), out))
+	<-w.ledger.stubs[ch].ChaincodeEventsChannel
+	if !assert.Len(w.ledger.t, out.SwapKeyResponses, 1) {
+		return nil
+	}
+	return out.SwapKeyResponses[0]
+}
+
+// SweepExpiredSwaps calls the robot's sweep of expired swaps and returns responses and the accounting event
+func (w *Wallet) SweepExpiredSwaps(ch string, limit int) (*proto.BatchResponse, *proto.BatchTxEvent) {
+	return w.sweep(ch, "sweepExpiredSwaps", strconv.Itoa(limit))
+}
+
+// DoBatchWithSweep executes the batch which only sweeps expired swaps
+func (w *Wallet) DoBatchWithSweep(ch string, limit uint32) (*proto.BatchResponse, *proto.BatchTxEvent) {
+	data, err := pb.Marshal(&proto.Batch{SweepExpiredSwaps: limit})
+	assert.NoError(w.ledger.t, err)
+	return w.sweep(ch, "batchExecute", string(data))
+}
+
+func (w *Wallet) sweep(ch string, fn string, arg string) (*proto.BatchResponse, *proto.BatchTxEvent) {
+	cert, err := hex.DecodeString(batchRobotCert)
+	assert.NoError(w.ledger.t, err)
+	w.ledger.stubs[ch].SetCreator(cert)
+	res := w.Invoke(ch, fn, arg)
+	out := &proto.BatchResponse{}
+	assert.NoError(w.ledger.t, pb.Unmarshal([]byte(res), out))
+
+	// events of earlier invokes which were not read are queued before the sweep event
+	eventsCh := w.ledger.stubs[ch].ChaincodeEventsChannel
+	for e := <-eventsCh; ; e = <-eventsCh {
+		if e.EventName != fn {
+			continue
+		}
+		events := &proto.BatchEvent{}
+		assert.NoError(w.ledger.t, pb.Unmarshal(e.Payload, events))
+		for _, ev := range events.Events {
+			if ev.Method == "sweepExpiredSwaps" {
+				return out, ev
+			}
+		}
+	}
+}
+
 func (br BatchTxResponse) TxHasNoError(t *testing.T, txID ...string) {
 	for _, id := range txID {
 		res, ok := br[id]
@@ -310,36 +395,51 @@ func (w *Wallet) RawSignedMultiSwapInvoke(ch string, fn string, args ...string)
 	out := &proto.BatchResponse{}
 	assert.NoError(w.ledger.t, pb.Unmarshal([]byte(res), out))
 
-	e := <-w.ledger.stubs[ch].ChaincodeEventsChannel
-	if e.EventName == "batchExecute" {
-		events := &proto.BatchEvent{}
-		assert.NoError(w.ledger.t, pb.Unmarshal(e.Payload, events))
-		for _, ev := range events.Events {
-			if hex.EncodeToString(ev.Id) == txID {
-				evts := make(map[string][]byte)
-				for _, evt := range ev.Events {
-					evts[evt.Name] = evt.Value
-				}
-				er := ""
-				if ev.Error != nil {
-					er = ev.Error.Error
+	// events of earlier invokes which were not read are queued before the batch event
+	eventsCh := w.ledger.stubs[ch].ChaincodeEventsChannel
+	for e := <-eventsCh; ; e = <-eventsCh {
+		if e.EventName == "batchExecute" {
+			events := &proto.BatchEvent{}
+			assert.NoError(w.ledger.t, pb.Unmarshal(e.Payload, events))
+			for _, ev := range events.Events {
+				if hex.EncodeToString(ev.Id) == txID {
+					evts := make(map[string][]byte)
+					for _, evt := range ev.Events {
+						evts[evt.Name] = evt.Value
+					}
+					er := ""
+					if ev.Error != nil {
+						er = ev.Error.Error
+					}
+					return txID, TxResponse{
+						Method: ev.Method,
+						Error:  er,
+						Result: string(ev.Result),
+						Events: evts,
+					}, out.CreatedSwaps, out.CreatedMultiSwap
 				}
-				return txID, TxResponse{
-					Method: ev.Method,
-					Error:  er,
-					Result: string(ev.Result),
-					Events: evts,
-				}, out.CreatedSwaps, out.CreatedMultiSwap
 			}
 		}
+		if len(eventsCh) == 0 {
+			break
+		}
 	}
 	assert.Fail(w.ledger.t, shouldNotBeHereMsg)
 	return txID, TxResponse{}, out.CreatedSwaps, out.CreatedMultiSwap
 }
 
 func (w *Wallet) RawSignedInvokeWithErrorReturned(ch string, fn string, args ...string) error {
-	txID := txIDGen()
 	args, _ = w.sign(fn, ch, args...)
+	return w.invokeSignedWithErrorReturned(ch, fn, args)
+}
+
+// RawSignedInvokeWithDeadline signs args with deadline (unix seconds) and executes them in a batch
+func (w *Wallet) RawSignedInvokeWithDeadline(deadline int64, ch string, fn string, args ...string) error {
+	return w.invokeSignedWithErrorReturned(ch, fn, w.signWithDeadline(fn, ch, deadline, args...))
+}
+
+func (w *Wallet) invokeSignedWithErrorReturned(ch string, fn string, args []string) error {
+	txID := txIDGen()
 	cert, err := base64.StdEncoding.DecodeString(userCert)
 	assert.NoError(w.ledger.t, err)
 	_ = w.ledger.stubs[ch].SetCreatorCert("atomyzeMSP", cert)
@@ -396,26 +496,36 @@ func (w *Wallet) RawSignedInvokeWithErrorReturned(ch string, fn string, args ...
 func (w *Wallet) SignedInvoke(ch string, fn string, args ...string) string {
 	txID, res, swaps := w.RawSignedInvoke(ch, fn, args...)
 	assert.Equal(w.ledger.t, "", res.Error)
+	w.answerSwaps(swaps)
+	return txID
+}
+
+// answerSwaps answers swaps like the robot does, swaps forwarded by answers are answered too
+func (w *Wallet) answerSwaps(swaps []*proto.Swap) {
 	for _, swap := range swaps {
 		x := proto.Batch{Swaps: []*proto.Swap{{
-			Id:      swap.Id,
-			Creator: []byte("0000"),
-			Owner:   swap.Owner,
-			Token:   swap.Token,
-			Amount:  swap.Amount,
-			From:    swap.From,
-			To:      swap.To,
-			Hash:    swap.Hash,
-			Timeout: swap.Timeout,
+			Id:            swap.Id,
+			Creator:       []byte("0000"),
+			Owner:         swap.Owner,
+			Token:         swap.Token,
+			Amount:        swap.Amount,
+			From:          swap.From,
+			To:            swap.To,
+			Hash:          swap.Hash,
+			Timeout:       swap.Timeout,
+			Route:         swap.Route,
+			ForwardedFrom: swap.ForwardedFrom,
 		}}}
 		data, err := pb.Marshal(&x)
 		assert.NoError(w.ledger.t, err)
 		cert, err := hex.DecodeString(batchRobotCert)
 		assert.NoError(w.ledger.t, err)
 		w.ledger.stubs[strings.ToLower(swap.To)].SetCreator(cert)
-		w.Invoke(strings.ToLower(swap.To), "batchExecute", string(data))
+		res := w.Invoke(strings.ToLower(swap.To), "batchExecute", string(data))
+		out := &proto.BatchResponse{}
+		assert.NoError(w.ledger.t, pb.Unmarshal([]byte(res), out))
+		w.answerSwaps(out.CreatedSwaps)
 	}
-	return txID
 }
 
 func (w *Wallet) SignedMultiSwapsInvoke(ch string, fn string, args ...string) string {
@@ -447,6 +557,15 @@ func (w *Wallet) SignedMultiSwapsInvoke(ch string, fn string, args ...string) st
 	return txID
 }
 
+// SignNested signs args and returns them as base58 encoded proto.Nested without invoking,
+// so they can be sent by a relayer
+func (w *Wallet) SignNested(ch string, fn string, args ...string) string {
+	message, _ := w.sign(fn, ch, args...)
+	nested, err := pb.Marshal(&proto.Nested{Args: append([]string{fn}, message...)})
+	assert.NoError(w.ledger.t, err)
+	return base58.Encode(nested)
+}
+
 func (w *Wallet) OtfNbInvoke(ch string, fn string, args ...string) (string, string) {
 	txID := txIDGen()
 	message, hash := w.sign(fn, ch, args...)
@@ -191,15 +191,20 @@ type Swap struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id      []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Creator []byte `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
-	Owner   []byte `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
-	Token   string `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
-	Amount  []byte `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
-	From    string `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
-	To      string `protobuf:"bytes,7,opt,name=to,proto3" json:"to,omitempty"`
-	Hash    []byte `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`
-	Timeout int64  `protobuf:"varint,9,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Id            []byte        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Creator       []byte        `protobuf:"bytes,2,opt,name=creator,proto3" json:"creator,omitempty"`
+	Owner         []byte        `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	Token         string        `protobuf:"bytes,4,opt,name=token,proto3" json:"token,omitempty"`
+	Amount        []byte        `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	From          string        `protobuf:"bytes,6,opt,name=from,proto3" json:"from,omitempty"`
+	To            string        `protobuf:"bytes,7,opt,name=to,proto3" json:"to,omitempty"`
+	Hash          []byte        `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`
+	Timeout       int64         `protobuf:"varint,9,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Fee           []byte        `protobuf:"bytes,10,opt,name=fee,proto3" json:"fee,omitempty"`
+	FeeCurrency   string        `protobuf:"bytes,11,opt,name=fee_currency,json=feeCurrency,proto3" json:"fee_currency,omitempty"`
+	Route         []string      `protobuf:"bytes,13,rep,name=route,proto3" json:"route,omitempty"`
+	ForwardedFrom string        `protobuf:"bytes,14,opt,name=forwarded_from,json=forwardedFrom,proto3" json:"forwarded_from,omitempty"`
+	FeeLegs       []*SwapFeeLeg `protobuf:"bytes,15,rep,name=fee_legs,json=feeLegs,proto3" json:"fee_legs,omitempty"`
 }
 
 func (x *Swap) Reset() {
@@ -297,6 +302,96 @@ func (x *Swap) GetTimeout() int64 {
 	return 0
 }
 
+func (x *Swap) GetFee() []byte {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *Swap) GetFeeCurrency() string {
+	if x != nil {
+		return x.FeeCurrency
+	}
+	return ""
+}
+
+func (x *Swap) GetRoute() []string {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *Swap) GetForwardedFrom() string {
+	if x != nil {
+		return x.ForwardedFrom
+	}
+	return ""
+}
+
+func (x *Swap) GetFeeLegs() []*SwapFeeLeg {
+	if x != nil {
+		return x.FeeLegs
+	}
+	return nil
+}
+
+type SwapFeeLeg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount  []byte `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *SwapFeeLeg) Reset() {
+	*x = SwapFeeLeg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapFeeLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapFeeLeg) ProtoMessage() {}
+
+func (x *SwapFeeLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapFeeLeg.ProtoReflect.Descriptor instead.
+func (*SwapFeeLeg) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SwapFeeLeg) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *SwapFeeLeg) GetAmount() []byte {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
 type SwapKey struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -309,7 +404,7 @@ type SwapKey struct {
 func (x *SwapKey) Reset() {
 	*x = SwapKey{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[3]
+		mi := &file_batch_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -322,7 +417,7 @@ func (x *SwapKey) String() string {
 func (*SwapKey) ProtoMessage() {}
 
 func (x *SwapKey) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[3]
+	mi := &file_batch_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,7 +430,7 @@ func (x *SwapKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SwapKey.ProtoReflect.Descriptor instead.
 func (*SwapKey) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{3}
+	return file_batch_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *SwapKey) GetId() []byte {
@@ -357,17 +452,18 @@ type Batch struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxIDs          [][]byte     `protobuf:"bytes,1,rep,name=txIDs,proto3" json:"txIDs,omitempty"`
-	Swaps          []*Swap      `protobuf:"bytes,2,rep,name=swaps,proto3" json:"swaps,omitempty"`
-	Keys           []*SwapKey   `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
-	MultiSwapsKeys []*SwapKey   `protobuf:"bytes,4,rep,name=multi_swaps_keys,json=multiSwapsKeys,proto3" json:"multi_swaps_keys,omitempty"`
-	MultiSwaps     []*MultiSwap `protobuf:"bytes,5,rep,name=multi_swaps,json=multiSwaps,proto3" json:"multi_swaps,omitempty"`
+	TxIDs             [][]byte     `protobuf:"bytes,1,rep,name=txIDs,proto3" json:"txIDs,omitempty"`
+	Swaps             []*Swap      `protobuf:"bytes,2,rep,name=swaps,proto3" json:"swaps,omitempty"`
+	Keys              []*SwapKey   `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	MultiSwapsKeys    []*SwapKey   `protobuf:"bytes,4,rep,name=multi_swaps_keys,json=multiSwapsKeys,proto3" json:"multi_swaps_keys,omitempty"`
+	MultiSwaps        []*MultiSwap `protobuf:"bytes,5,rep,name=multi_swaps,json=multiSwaps,proto3" json:"multi_swaps,omitempty"`
+	SweepExpiredSwaps uint32       `protobuf:"varint,6,opt,name=sweep_expired_swaps,json=sweepExpiredSwaps,proto3" json:"sweep_expired_swaps,omitempty"`
 }
 
 func (x *Batch) Reset() {
 	*x = Batch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[4]
+		mi := &file_batch_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -380,7 +476,7 @@ func (x *Batch) String() string {
 func (*Batch) ProtoMessage() {}
 
 func (x *Batch) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[4]
+	mi := &file_batch_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -393,7 +489,7 @@ func (x *Batch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Batch.ProtoReflect.Descriptor instead.
 func (*Batch) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{4}
+	return file_batch_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Batch) GetTxIDs() [][]byte {
@@ -431,6 +527,13 @@ func (x *Batch) GetMultiSwaps() []*MultiSwap {
 	return nil
 }
 
+func (x *Batch) GetSweepExpiredSwaps() uint32 {
+	if x != nil {
+		return x.SweepExpiredSwaps
+	}
+	return 0
+}
+
 type InitArgs struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -444,7 +547,7 @@ type InitArgs struct {
 func (x *InitArgs) Reset() {
 	*x = InitArgs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[5]
+		mi := &file_batch_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -457,7 +560,7 @@ func (x *InitArgs) String() string {
 func (*InitArgs) ProtoMessage() {}
 
 func (x *InitArgs) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[5]
+	mi := &file_batch_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -470,7 +573,7 @@ func (x *InitArgs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InitArgs.ProtoReflect.Descriptor instead.
 func (*InitArgs) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{5}
+	return file_batch_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *InitArgs) GetAtomyzeSKI() []byte {
@@ -507,7 +610,7 @@ type WriteElement struct {
 func (x *WriteElement) Reset() {
 	*x = WriteElement{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[6]
+		mi := &file_batch_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -520,7 +623,7 @@ func (x *WriteElement) String() string {
 func (*WriteElement) ProtoMessage() {}
 
 func (x *WriteElement) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[6]
+	mi := &file_batch_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -533,7 +636,7 @@ func (x *WriteElement) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WriteElement.ProtoReflect.Descriptor instead.
 func (*WriteElement) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{6}
+	return file_batch_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *WriteElement) GetKey() string {
@@ -569,7 +672,7 @@ type ResponseError struct {
 func (x *ResponseError) Reset() {
 	*x = ResponseError{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[7]
+		mi := &file_batch_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -582,7 +685,7 @@ func (x *ResponseError) String() string {
 func (*ResponseError) ProtoMessage() {}
 
 func (x *ResponseError) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[7]
+	mi := &file_batch_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -595,7 +698,7 @@ func (x *ResponseError) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResponseError.ProtoReflect.Descriptor instead.
 func (*ResponseError) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{7}
+	return file_batch_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ResponseError) GetCode() int32 {
@@ -620,12 +723,13 @@ type SwapResponse struct {
 	Id     []byte          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Error  *ResponseError  `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	Writes []*WriteElement `protobuf:"bytes,3,rep,name=writes,proto3" json:"writes,omitempty"`
+	Events []*Event        `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
 }
 
 func (x *SwapResponse) Reset() {
 	*x = SwapResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[8]
+		mi := &file_batch_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -638,7 +742,7 @@ func (x *SwapResponse) String() string {
 func (*SwapResponse) ProtoMessage() {}
 
 func (x *SwapResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[8]
+	mi := &file_batch_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -651,7 +755,7 @@ func (x *SwapResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SwapResponse.ProtoReflect.Descriptor instead.
 func (*SwapResponse) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{8}
+	return file_batch_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *SwapResponse) GetId() []byte {
@@ -675,22 +779,111 @@ func (x *SwapResponse) GetWrites() []*WriteElement {
 	return nil
 }
 
+func (x *SwapResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type HoldResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner  []byte          `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Error  *ResponseError  `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Writes []*WriteElement `protobuf:"bytes,4,rep,name=writes,proto3" json:"writes,omitempty"`
+	Events []*Event        `protobuf:"bytes,5,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *HoldResponse) Reset() {
+	*x = HoldResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HoldResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HoldResponse) ProtoMessage() {}
+
+func (x *HoldResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HoldResponse.ProtoReflect.Descriptor instead.
+func (*HoldResponse) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *HoldResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HoldResponse) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *HoldResponse) GetError() *ResponseError {
+	if x != nil {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *HoldResponse) GetWrites() []*WriteElement {
+	if x != nil {
+		return x.Writes
+	}
+	return nil
+}
+
+func (x *HoldResponse) GetEvents() []*Event {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
 type AccountingRecord struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Token     string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	Sender    []byte `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
-	Recipient []byte `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
-	Amount    []byte `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
-	Reason    string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Token       string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Sender      []byte `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Recipient   []byte `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Amount      []byte `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reason      string `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	Relayer     []byte `protobuf:"bytes,6,opt,name=relayer,proto3" json:"relayer,omitempty"`
+	Ref         string `protobuf:"bytes,7,opt,name=ref,proto3" json:"ref,omitempty"`
+	RateVersion uint64 `protobuf:"varint,8,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
 }
 
 func (x *AccountingRecord) Reset() {
 	*x = AccountingRecord{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[9]
+		mi := &file_batch_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -703,7 +896,7 @@ func (x *AccountingRecord) String() string {
 func (*AccountingRecord) ProtoMessage() {}
 
 func (x *AccountingRecord) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[9]
+	mi := &file_batch_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -716,7 +909,7 @@ func (x *AccountingRecord) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountingRecord.ProtoReflect.Descriptor instead.
 func (*AccountingRecord) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{9}
+	return file_batch_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *AccountingRecord) GetToken() string {
@@ -754,32 +947,54 @@ func (x *AccountingRecord) GetReason() string {
 	return ""
 }
 
-type Event struct {
+func (x *AccountingRecord) GetRelayer() []byte {
+	if x != nil {
+		return x.Relayer
+	}
+	return nil
+}
+
+func (x *AccountingRecord) GetRef() string {
+	if x != nil {
+		return x.Ref
+	}
+	return ""
+}
+
+func (x *AccountingRecord) GetRateVersion() uint64 {
+	if x != nil {
+		return x.RateVersion
+	}
+	return 0
+}
+
+type TransferRef struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	TxId       string              `protobuf:"bytes,1,opt,name=tx_id,json=txId,proto3" json:"tx_id,omitempty"`
+	Timestamp  int64               `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Accounting []*AccountingRecord `protobuf:"bytes,3,rep,name=accounting,proto3" json:"accounting,omitempty"`
 }
 
-func (x *Event) Reset() {
-	*x = Event{}
+func (x *TransferRef) Reset() {
+	*x = TransferRef{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[10]
+		mi := &file_batch_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Event) String() string {
+func (x *TransferRef) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Event) ProtoMessage() {}
+func (*TransferRef) ProtoMessage() {}
 
-func (x *Event) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[10]
+func (x *TransferRef) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -790,53 +1005,58 @@ func (x *Event) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Event.ProtoReflect.Descriptor instead.
-func (*Event) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use TransferRef.ProtoReflect.Descriptor instead.
+func (*TransferRef) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Event) GetName() string {
+func (x *TransferRef) GetTxId() string {
 	if x != nil {
-		return x.Name
+		return x.TxId
 	}
 	return ""
 }
 
-func (x *Event) GetValue() []byte {
+func (x *TransferRef) GetTimestamp() int64 {
 	if x != nil {
-		return x.Value
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *TransferRef) GetAccounting() []*AccountingRecord {
+	if x != nil {
+		return x.Accounting
 	}
 	return nil
 }
 
-type TxResponse struct {
+type Event struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id     []byte          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Method string          `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
-	Error  *ResponseError  `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
-	Writes []*WriteElement `protobuf:"bytes,4,rep,name=writes,proto3" json:"writes,omitempty"`
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 }
 
-func (x *TxResponse) Reset() {
-	*x = TxResponse{}
+func (x *Event) Reset() {
+	*x = Event{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[11]
+		mi := &file_batch_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TxResponse) String() string {
+func (x *Event) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TxResponse) ProtoMessage() {}
+func (*Event) ProtoMessage() {}
 
-func (x *TxResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[11]
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -847,9 +1067,66 @@ func (x *TxResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TxResponse.ProtoReflect.Descriptor instead.
-func (*TxResponse) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Event) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Event) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type TxResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     []byte          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Method string          `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	Error  *ResponseError  `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Writes []*WriteElement `protobuf:"bytes,4,rep,name=writes,proto3" json:"writes,omitempty"`
+}
+
+func (x *TxResponse) Reset() {
+	*x = TxResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TxResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TxResponse) ProtoMessage() {}
+
+func (x *TxResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TxResponse.ProtoReflect.Descriptor instead.
+func (*TxResponse) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *TxResponse) GetId() []byte {
@@ -891,12 +1168,13 @@ type BatchTxEvent struct {
 	Result     []byte              `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
 	Events     []*Event            `protobuf:"bytes,6,rep,name=events,proto3" json:"events,omitempty"`
 	Accounting []*AccountingRecord `protobuf:"bytes,7,rep,name=accounting,proto3" json:"accounting,omitempty"`
+	Refs       []string            `protobuf:"bytes,8,rep,name=refs,proto3" json:"refs,omitempty"`
 }
 
 func (x *BatchTxEvent) Reset() {
 	*x = BatchTxEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[12]
+		mi := &file_batch_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -909,7 +1187,7 @@ func (x *BatchTxEvent) String() string {
 func (*BatchTxEvent) ProtoMessage() {}
 
 func (x *BatchTxEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[12]
+	mi := &file_batch_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -922,7 +1200,7 @@ func (x *BatchTxEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchTxEvent.ProtoReflect.Descriptor instead.
 func (*BatchTxEvent) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{12}
+	return file_batch_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *BatchTxEvent) GetId() []byte {
@@ -967,6 +1245,13 @@ func (x *BatchTxEvent) GetAccounting() []*AccountingRecord {
 	return nil
 }
 
+func (x *BatchTxEvent) GetRefs() []string {
+	if x != nil {
+		return x.Refs
+	}
+	return nil
+}
+
 type BatchEvent struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -978,7 +1263,7 @@ type BatchEvent struct {
 func (x *BatchEvent) Reset() {
 	*x = BatchEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[13]
+		mi := &file_batch_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -991,7 +1276,7 @@ func (x *BatchEvent) String() string {
 func (*BatchEvent) ProtoMessage() {}
 
 func (x *BatchEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[13]
+	mi := &file_batch_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1004,7 +1289,7 @@ func (x *BatchEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchEvent.ProtoReflect.Descriptor instead.
 func (*BatchEvent) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{13}
+	return file_batch_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *BatchEvent) GetEvents() []*BatchTxEvent {
@@ -1019,17 +1304,19 @@ type BatchResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TxResponses      []*TxResponse   `protobuf:"bytes,1,rep,name=tx_responses,json=txResponses,proto3" json:"tx_responses,omitempty"`
-	CreatedSwaps     []*Swap         `protobuf:"bytes,2,rep,name=created_swaps,json=createdSwaps,proto3" json:"created_swaps,omitempty"`
-	SwapResponses    []*SwapResponse `protobuf:"bytes,3,rep,name=swap_responses,json=swapResponses,proto3" json:"swap_responses,omitempty"`
-	SwapKeyResponses []*SwapResponse `protobuf:"bytes,4,rep,name=swap_key_responses,json=swapKeyResponses,proto3" json:"swap_key_responses,omitempty"`
-	CreatedMultiSwap []*MultiSwap    `protobuf:"bytes,5,rep,name=created_multi_swap,json=createdMultiSwap,proto3" json:"created_multi_swap,omitempty"`
+	TxResponses        []*TxResponse   `protobuf:"bytes,1,rep,name=tx_responses,json=txResponses,proto3" json:"tx_responses,omitempty"`
+	CreatedSwaps       []*Swap         `protobuf:"bytes,2,rep,name=created_swaps,json=createdSwaps,proto3" json:"created_swaps,omitempty"`
+	SwapResponses      []*SwapResponse `protobuf:"bytes,3,rep,name=swap_responses,json=swapResponses,proto3" json:"swap_responses,omitempty"`
+	SwapKeyResponses   []*SwapResponse `protobuf:"bytes,4,rep,name=swap_key_responses,json=swapKeyResponses,proto3" json:"swap_key_responses,omitempty"`
+	CreatedMultiSwap   []*MultiSwap    `protobuf:"bytes,5,rep,name=created_multi_swap,json=createdMultiSwap,proto3" json:"created_multi_swap,omitempty"`
+	SweptSwapResponses []*SwapResponse `protobuf:"bytes,6,rep,name=swept_swap_responses,json=sweptSwapResponses,proto3" json:"swept_swap_responses,omitempty"`
+	SweptHoldResponses []*HoldResponse `protobuf:"bytes,7,rep,name=swept_hold_responses,json=sweptHoldResponses,proto3" json:"swept_hold_responses,omitempty"`
 }
 
 func (x *BatchResponse) Reset() {
 	*x = BatchResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[14]
+		mi := &file_batch_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1042,7 +1329,7 @@ func (x *BatchResponse) String() string {
 func (*BatchResponse) ProtoMessage() {}
 
 func (x *BatchResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[14]
+	mi := &file_batch_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1055,7 +1342,7 @@ func (x *BatchResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BatchResponse.ProtoReflect.Descriptor instead.
 func (*BatchResponse) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{14}
+	return file_batch_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *BatchResponse) GetTxResponses() []*TxResponse {
@@ -1093,6 +1380,20 @@ func (x *BatchResponse) GetCreatedMultiSwap() []*MultiSwap {
 	return nil
 }
 
+func (x *BatchResponse) GetSweptSwapResponses() []*SwapResponse {
+	if x != nil {
+		return x.SweptSwapResponses
+	}
+	return nil
+}
+
+func (x *BatchResponse) GetSweptHoldResponses() []*HoldResponse {
+	if x != nil {
+		return x.SweptHoldResponses
+	}
+	return nil
+}
+
 type Nested struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -1104,7 +1405,7 @@ type Nested struct {
 func (x *Nested) Reset() {
 	*x = Nested{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[15]
+		mi := &file_batch_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1117,7 +1418,7 @@ func (x *Nested) String() string {
 func (*Nested) ProtoMessage() {}
 
 func (x *Nested) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[15]
+	mi := &file_batch_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1130,7 +1431,7 @@ func (x *Nested) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Nested.ProtoReflect.Descriptor instead.
 func (*Nested) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{15}
+	return file_batch_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *Nested) GetArgs() []string {
@@ -1145,16 +1446,17 @@ type TokenFee struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Currency string `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
-	Fee      []byte `protobuf:"bytes,2,opt,name=fee,proto3" json:"fee,omitempty"`
-	Floor    []byte `protobuf:"bytes,3,opt,name=floor,proto3" json:"floor,omitempty"`
-	Cap      []byte `protobuf:"bytes,4,opt,name=cap,proto3" json:"cap,omitempty"`
+	Currency string          `protobuf:"bytes,1,opt,name=currency,proto3" json:"currency,omitempty"`
+	Fee      []byte          `protobuf:"bytes,2,opt,name=fee,proto3" json:"fee,omitempty"`
+	Floor    []byte          `protobuf:"bytes,3,opt,name=floor,proto3" json:"floor,omitempty"`
+	Cap      []byte          `protobuf:"bytes,4,opt,name=cap,proto3" json:"cap,omitempty"`
+	Tiers    []*TokenFeeTier `protobuf:"bytes,5,rep,name=tiers,proto3" json:"tiers,omitempty"`
 }
 
 func (x *TokenFee) Reset() {
 	*x = TokenFee{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[16]
+		mi := &file_batch_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1167,7 +1469,7 @@ func (x *TokenFee) String() string {
 func (*TokenFee) ProtoMessage() {}
 
 func (x *TokenFee) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[16]
+	mi := &file_batch_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1180,7 +1482,7 @@ func (x *TokenFee) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenFee.ProtoReflect.Descriptor instead.
 func (*TokenFee) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{16}
+	return file_batch_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *TokenFee) GetCurrency() string {
@@ -1211,36 +1513,39 @@ func (x *TokenFee) GetCap() []byte {
 	return nil
 }
 
-type TokenRate struct {
+func (x *TokenFee) GetTiers() []*TokenFeeTier {
+	if x != nil {
+		return x.Tiers
+	}
+	return nil
+}
+
+type TokenFeeTier struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	DealType string   `protobuf:"bytes,1,opt,name=deal_type,json=dealType,proto3" json:"deal_type,omitempty"`
-	Currency string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
-	Rate     []byte   `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
-	Min      []byte   `protobuf:"bytes,4,opt,name=min,proto3" json:"min,omitempty"`
-	Max      []byte   `protobuf:"bytes,5,opt,name=max,proto3" json:"max,omitempty"`
-	Issuer   *Address `protobuf:"bytes,6,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	From []byte `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	Fee  []byte `protobuf:"bytes,2,opt,name=fee,proto3" json:"fee,omitempty"`
 }
 
-func (x *TokenRate) Reset() {
-	*x = TokenRate{}
+func (x *TokenFeeTier) Reset() {
+	*x = TokenFeeTier{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[17]
+		mi := &file_batch_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *TokenRate) String() string {
+func (x *TokenFeeTier) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TokenRate) ProtoMessage() {}
+func (*TokenFeeTier) ProtoMessage() {}
 
-func (x *TokenRate) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[17]
+func (x *TokenFeeTier) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1251,81 +1556,113 @@ func (x *TokenRate) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TokenRate.ProtoReflect.Descriptor instead.
-func (*TokenRate) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use TokenFeeTier.ProtoReflect.Descriptor instead.
+func (*TokenFeeTier) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *TokenRate) GetDealType() string {
+func (x *TokenFeeTier) GetFrom() []byte {
 	if x != nil {
-		return x.DealType
+		return x.From
 	}
-	return ""
+	return nil
 }
 
-func (x *TokenRate) GetCurrency() string {
+func (x *TokenFeeTier) GetFee() []byte {
 	if x != nil {
-		return x.Currency
+		return x.Fee
 	}
-	return ""
+	return nil
 }
 
-func (x *TokenRate) GetRate() []byte {
-	if x != nil {
-		return x.Rate
+type FeeSplit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address     []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	BasisPoints uint32 `protobuf:"varint,2,opt,name=basis_points,json=basisPoints,proto3" json:"basis_points,omitempty"`
+}
+
+func (x *FeeSplit) Reset() {
+	*x = FeeSplit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return nil
 }
 
-func (x *TokenRate) GetMin() []byte {
-	if x != nil {
-		return x.Min
+func (x *FeeSplit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeeSplit) ProtoMessage() {}
+
+func (x *FeeSplit) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *TokenRate) GetMax() []byte {
+// Deprecated: Use FeeSplit.ProtoReflect.Descriptor instead.
+func (*FeeSplit) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *FeeSplit) GetAddress() []byte {
 	if x != nil {
-		return x.Max
+		return x.Address
 	}
 	return nil
 }
 
-func (x *TokenRate) GetIssuer() *Address {
+func (x *FeeSplit) GetBasisPoints() uint32 {
 	if x != nil {
-		return x.Issuer
+		return x.BasisPoints
 	}
-	return nil
+	return 0
 }
 
-type Token struct {
+type TokenRate struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	TotalEmission []byte       `protobuf:"bytes,1,opt,name=total_emission,json=totalEmission,proto3" json:"total_emission,omitempty"`
-	Fee           *TokenFee    `protobuf:"bytes,2,opt,name=fee,proto3" json:"fee,omitempty"`
-	Rates         []*TokenRate `protobuf:"bytes,3,rep,name=rates,proto3" json:"rates,omitempty"`
-	FeeAddress    []byte       `protobuf:"bytes,4,opt,name=fee_address,json=feeAddress,proto3" json:"fee_address,omitempty"`
+	DealType  string   `protobuf:"bytes,1,opt,name=deal_type,json=dealType,proto3" json:"deal_type,omitempty"`
+	Currency  string   `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Rate      []byte   `protobuf:"bytes,3,opt,name=rate,proto3" json:"rate,omitempty"`
+	Min       []byte   `protobuf:"bytes,4,opt,name=min,proto3" json:"min,omitempty"`
+	Max       []byte   `protobuf:"bytes,5,opt,name=max,proto3" json:"max,omitempty"`
+	Issuer    *Address `protobuf:"bytes,6,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	ValidFrom int64    `protobuf:"varint,7,opt,name=valid_from,json=validFrom,proto3" json:"valid_from,omitempty"`
+	ValidTo   int64    `protobuf:"varint,8,opt,name=valid_to,json=validTo,proto3" json:"valid_to,omitempty"`
+	Version   uint64   `protobuf:"varint,9,opt,name=version,proto3" json:"version,omitempty"`
 }
 
-func (x *Token) Reset() {
-	*x = Token{}
+func (x *TokenRate) Reset() {
+	*x = TokenRate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[18]
+		mi := &file_batch_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Token) String() string {
+func (x *TokenRate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Token) ProtoMessage() {}
+func (*TokenRate) ProtoMessage() {}
 
-func (x *Token) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[18]
+func (x *TokenRate) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1336,64 +1673,101 @@ func (x *Token) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Token.ProtoReflect.Descriptor instead.
-func (*Token) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use TokenRate.ProtoReflect.Descriptor instead.
+func (*TokenRate) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *Token) GetTotalEmission() []byte {
+func (x *TokenRate) GetDealType() string {
 	if x != nil {
-		return x.TotalEmission
+		return x.DealType
+	}
+	return ""
+}
+
+func (x *TokenRate) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *TokenRate) GetRate() []byte {
+	if x != nil {
+		return x.Rate
 	}
 	return nil
 }
 
-func (x *Token) GetFee() *TokenFee {
+func (x *TokenRate) GetMin() []byte {
 	if x != nil {
-		return x.Fee
+		return x.Min
 	}
 	return nil
 }
 
-func (x *Token) GetRates() []*TokenRate {
+func (x *TokenRate) GetMax() []byte {
 	if x != nil {
-		return x.Rates
+		return x.Max
 	}
 	return nil
 }
 
-func (x *Token) GetFeeAddress() []byte {
+func (x *TokenRate) GetIssuer() *Address {
 	if x != nil {
-		return x.FeeAddress
+		return x.Issuer
 	}
 	return nil
 }
 
-type HaveRight struct {
+func (x *TokenRate) GetValidFrom() int64 {
+	if x != nil {
+		return x.ValidFrom
+	}
+	return 0
+}
+
+func (x *TokenRate) GetValidTo() int64 {
+	if x != nil {
+		return x.ValidTo
+	}
+	return 0
+}
+
+func (x *TokenRate) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type TokenRateHistory struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	HaveRight bool `protobuf:"varint,1,opt,name=haveRight,proto3" json:"haveRight,omitempty"`
+	Rate      *TokenRate `protobuf:"bytes,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	Timestamp int64      `protobuf:"varint,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Action    string     `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
 }
 
-func (x *HaveRight) Reset() {
-	*x = HaveRight{}
+func (x *TokenRateHistory) Reset() {
+	*x = TokenRateHistory{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[19]
+		mi := &file_batch_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *HaveRight) String() string {
+func (x *TokenRateHistory) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HaveRight) ProtoMessage() {}
+func (*TokenRateHistory) ProtoMessage() {}
 
-func (x *HaveRight) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[19]
+func (x *TokenRateHistory) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1404,9 +1778,173 @@ func (x *HaveRight) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HaveRight.ProtoReflect.Descriptor instead.
-func (*HaveRight) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use TokenRateHistory.ProtoReflect.Descriptor instead.
+func (*TokenRateHistory) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TokenRateHistory) GetRate() *TokenRate {
+	if x != nil {
+		return x.Rate
+	}
+	return nil
+}
+
+func (x *TokenRateHistory) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *TokenRateHistory) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+type Token struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalEmission []byte       `protobuf:"bytes,1,opt,name=total_emission,json=totalEmission,proto3" json:"total_emission,omitempty"`
+	Fee           *TokenFee    `protobuf:"bytes,2,opt,name=fee,proto3" json:"fee,omitempty"`
+	Rates         []*TokenRate `protobuf:"bytes,3,rep,name=rates,proto3" json:"rates,omitempty"`
+	FeeAddress    []byte       `protobuf:"bytes,4,opt,name=fee_address,json=feeAddress,proto3" json:"fee_address,omitempty"`
+	SwapFee       *TokenFee    `protobuf:"bytes,5,opt,name=swap_fee,json=swapFee,proto3" json:"swap_fee,omitempty"`
+	MaxSupply     []byte       `protobuf:"bytes,6,opt,name=max_supply,json=maxSupply,proto3" json:"max_supply,omitempty"`
+	FeeSplits     []*FeeSplit  `protobuf:"bytes,7,rep,name=fee_splits,json=feeSplits,proto3" json:"fee_splits,omitempty"`
+	RateVersion   uint64       `protobuf:"varint,8,opt,name=rate_version,json=rateVersion,proto3" json:"rate_version,omitempty"`
+}
+
+func (x *Token) Reset() {
+	*x = Token{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Token) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Token) ProtoMessage() {}
+
+func (x *Token) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Token.ProtoReflect.Descriptor instead.
+func (*Token) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Token) GetTotalEmission() []byte {
+	if x != nil {
+		return x.TotalEmission
+	}
+	return nil
+}
+
+func (x *Token) GetFee() *TokenFee {
+	if x != nil {
+		return x.Fee
+	}
+	return nil
+}
+
+func (x *Token) GetRates() []*TokenRate {
+	if x != nil {
+		return x.Rates
+	}
+	return nil
+}
+
+func (x *Token) GetFeeAddress() []byte {
+	if x != nil {
+		return x.FeeAddress
+	}
+	return nil
+}
+
+func (x *Token) GetSwapFee() *TokenFee {
+	if x != nil {
+		return x.SwapFee
+	}
+	return nil
+}
+
+func (x *Token) GetMaxSupply() []byte {
+	if x != nil {
+		return x.MaxSupply
+	}
+	return nil
+}
+
+func (x *Token) GetFeeSplits() []*FeeSplit {
+	if x != nil {
+		return x.FeeSplits
+	}
+	return nil
+}
+
+func (x *Token) GetRateVersion() uint64 {
+	if x != nil {
+		return x.RateVersion
+	}
+	return 0
+}
+
+type HaveRight struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HaveRight bool `protobuf:"varint,1,opt,name=haveRight,proto3" json:"haveRight,omitempty"`
+}
+
+func (x *HaveRight) Reset() {
+	*x = HaveRight{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HaveRight) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HaveRight) ProtoMessage() {}
+
+func (x *HaveRight) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HaveRight.ProtoReflect.Descriptor instead.
+func (*HaveRight) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *HaveRight) GetHaveRight() bool {
@@ -1432,7 +1970,7 @@ type Right struct {
 func (x *Right) Reset() {
 	*x = Right{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[20]
+		mi := &file_batch_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1445,7 +1983,7 @@ func (x *Right) String() string {
 func (*Right) ProtoMessage() {}
 
 func (x *Right) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[20]
+	mi := &file_batch_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1458,7 +1996,7 @@ func (x *Right) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Right.ProtoReflect.Descriptor instead.
 func (*Right) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{20}
+	return file_batch_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *Right) GetChannelName() string {
@@ -1515,7 +2053,7 @@ type AccountRights struct {
 func (x *AccountRights) Reset() {
 	*x = AccountRights{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[21]
+		mi := &file_batch_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1528,7 +2066,7 @@ func (x *AccountRights) String() string {
 func (*AccountRights) ProtoMessage() {}
 
 func (x *AccountRights) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[21]
+	mi := &file_batch_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1541,7 +2079,7 @@ func (x *AccountRights) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountRights.ProtoReflect.Descriptor instead.
 func (*AccountRights) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{21}
+	return file_batch_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *AccountRights) GetAddress() *Address {
@@ -1569,7 +2107,7 @@ type Accounts struct {
 func (x *Accounts) Reset() {
 	*x = Accounts{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[22]
+		mi := &file_batch_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1582,7 +2120,7 @@ func (x *Accounts) String() string {
 func (*Accounts) ProtoMessage() {}
 
 func (x *Accounts) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[22]
+	mi := &file_batch_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1595,7 +2133,7 @@ func (x *Accounts) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Accounts.ProtoReflect.Descriptor instead.
 func (*Accounts) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{22}
+	return file_batch_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *Accounts) GetAddresses() []*Address {
@@ -1616,7 +2154,7 @@ type Operations struct {
 func (x *Operations) Reset() {
 	*x = Operations{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[23]
+		mi := &file_batch_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1629,7 +2167,7 @@ func (x *Operations) String() string {
 func (*Operations) ProtoMessage() {}
 
 func (x *Operations) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[23]
+	mi := &file_batch_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1642,7 +2180,7 @@ func (x *Operations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Operations.ProtoReflect.Descriptor instead.
 func (*Operations) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{23}
+	return file_batch_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *Operations) GetOperations() []string {
@@ -1664,7 +2202,7 @@ type OperationRights struct {
 func (x *OperationRights) Reset() {
 	*x = OperationRights{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[24]
+		mi := &file_batch_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1677,7 +2215,7 @@ func (x *OperationRights) String() string {
 func (*OperationRights) ProtoMessage() {}
 
 func (x *OperationRights) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[24]
+	mi := &file_batch_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1690,7 +2228,7 @@ func (x *OperationRights) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OperationRights.ProtoReflect.Descriptor instead.
 func (*OperationRights) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{24}
+	return file_batch_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *OperationRights) GetOperationName() string {
@@ -1722,7 +2260,7 @@ type Industrial struct {
 func (x *Industrial) Reset() {
 	*x = Industrial{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[25]
+		mi := &file_batch_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1735,7 +2273,7 @@ func (x *Industrial) String() string {
 func (*Industrial) ProtoMessage() {}
 
 func (x *Industrial) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[25]
+	mi := &file_batch_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1748,7 +2286,7 @@ func (x *Industrial) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Industrial.ProtoReflect.Descriptor instead.
 func (*Industrial) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{25}
+	return file_batch_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *Industrial) GetGroups() []*IndustrialGroup {
@@ -1800,7 +2338,7 @@ type IndustrialGroup struct {
 func (x *IndustrialGroup) Reset() {
 	*x = IndustrialGroup{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[26]
+		mi := &file_batch_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1813,7 +2351,7 @@ func (x *IndustrialGroup) String() string {
 func (*IndustrialGroup) ProtoMessage() {}
 
 func (x *IndustrialGroup) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[26]
+	mi := &file_batch_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1826,7 +2364,7 @@ func (x *IndustrialGroup) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IndustrialGroup.ProtoReflect.Descriptor instead.
 func (*IndustrialGroup) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{26}
+	return file_batch_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *IndustrialGroup) GetId() string {
@@ -1870,7 +2408,7 @@ type AccountInfo struct {
 func (x *AccountInfo) Reset() {
 	*x = AccountInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[27]
+		mi := &file_batch_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1883,7 +2421,7 @@ func (x *AccountInfo) String() string {
 func (*AccountInfo) ProtoMessage() {}
 
 func (x *AccountInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[27]
+	mi := &file_batch_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1896,7 +2434,7 @@ func (x *AccountInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountInfo.ProtoReflect.Descriptor instead.
 func (*AccountInfo) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{27}
+	return file_batch_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *AccountInfo) GetKycHash() string {
@@ -1934,7 +2472,7 @@ type Address struct {
 func (x *Address) Reset() {
 	*x = Address{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[28]
+		mi := &file_batch_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1947,7 +2485,7 @@ func (x *Address) String() string {
 func (*Address) ProtoMessage() {}
 
 func (x *Address) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[28]
+	mi := &file_batch_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1960,7 +2498,7 @@ func (x *Address) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Address.ProtoReflect.Descriptor instead.
 func (*Address) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{28}
+	return file_batch_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *Address) GetUserID() string {
@@ -2006,7 +2544,7 @@ type SignedAddress struct {
 func (x *SignedAddress) Reset() {
 	*x = SignedAddress{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[29]
+		mi := &file_batch_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2019,7 +2557,7 @@ func (x *SignedAddress) String() string {
 func (*SignedAddress) ProtoMessage() {}
 
 func (x *SignedAddress) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[29]
+	mi := &file_batch_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2032,7 +2570,7 @@ func (x *SignedAddress) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignedAddress.ProtoReflect.Descriptor instead.
 func (*SignedAddress) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{29}
+	return file_batch_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *SignedAddress) GetAddress() *Address {
@@ -2083,7 +2621,7 @@ type SignaturePolicy struct {
 func (x *SignaturePolicy) Reset() {
 	*x = SignaturePolicy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[30]
+		mi := &file_batch_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2096,7 +2634,7 @@ func (x *SignaturePolicy) String() string {
 func (*SignaturePolicy) ProtoMessage() {}
 
 func (x *SignaturePolicy) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[30]
+	mi := &file_batch_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2109,7 +2647,7 @@ func (x *SignaturePolicy) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SignaturePolicy.ProtoReflect.Descriptor instead.
 func (*SignaturePolicy) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{30}
+	return file_batch_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *SignaturePolicy) GetN() uint32 {
@@ -2145,7 +2683,7 @@ type AclResponse struct {
 func (x *AclResponse) Reset() {
 	*x = AclResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[31]
+		mi := &file_batch_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2158,7 +2696,7 @@ func (x *AclResponse) String() string {
 func (*AclResponse) ProtoMessage() {}
 
 func (x *AclResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[31]
+	mi := &file_batch_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2171,7 +2709,7 @@ func (x *AclResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AclResponse.ProtoReflect.Descriptor instead.
 func (*AclResponse) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{31}
+	return file_batch_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *AclResponse) GetAccount() *AccountInfo {
@@ -2199,7 +2737,7 @@ type Nonce struct {
 func (x *Nonce) Reset() {
 	*x = Nonce{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[32]
+		mi := &file_batch_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2212,7 +2750,7 @@ func (x *Nonce) String() string {
 func (*Nonce) ProtoMessage() {}
 
 func (x *Nonce) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[32]
+	mi := &file_batch_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2225,7 +2763,7 @@ func (x *Nonce) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Nonce.ProtoReflect.Descriptor instead.
 func (*Nonce) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{32}
+	return file_batch_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *Nonce) GetNonce() []uint64 {
@@ -2246,25 +2784,850 @@ type PendingTx struct {
 	CreatorSKI []byte   `protobuf:"bytes,4,opt,name=creatorSKI,proto3" json:"creatorSKI,omitempty"`
 	Timestamp  int64    `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	Nonce      uint64   `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Deadline   int64    `protobuf:"varint,7,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Relay      *Relay   `protobuf:"bytes,8,opt,name=relay,proto3" json:"relay,omitempty"`
+}
+
+func (x *PendingTx) Reset() {
+	*x = PendingTx{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PendingTx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingTx) ProtoMessage() {}
+
+func (x *PendingTx) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingTx.ProtoReflect.Descriptor instead.
+func (*PendingTx) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PendingTx) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *PendingTx) GetSender() *Address {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *PendingTx) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *PendingTx) GetCreatorSKI() []byte {
+	if x != nil {
+		return x.CreatorSKI
+	}
+	return nil
+}
+
+func (x *PendingTx) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *PendingTx) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *PendingTx) GetDeadline() int64 {
+	if x != nil {
+		return x.Deadline
+	}
+	return 0
+}
+
+func (x *PendingTx) GetRelay() *Relay {
+	if x != nil {
+		return x.Relay
+	}
+	return nil
+}
+
+type Relay struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Relayer     *Address `protobuf:"bytes,1,opt,name=relayer,proto3" json:"relayer,omitempty"`
+	Nonce       uint64   `protobuf:"varint,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	FeeCurrency string   `protobuf:"bytes,3,opt,name=fee_currency,json=feeCurrency,proto3" json:"fee_currency,omitempty"`
+}
+
+func (x *Relay) Reset() {
+	*x = Relay{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Relay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Relay) ProtoMessage() {}
+
+func (x *Relay) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Relay.ProtoReflect.Descriptor instead.
+func (*Relay) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *Relay) GetRelayer() *Address {
+	if x != nil {
+		return x.Relayer
+	}
+	return nil
+}
+
+func (x *Relay) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *Relay) GetFeeCurrency() string {
+	if x != nil {
+		return x.FeeCurrency
+	}
+	return ""
+}
+
+type SwapLifecycle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Multi     bool   `protobuf:"varint,2,opt,name=multi,proto3" json:"multi,omitempty"`
+	Status    string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Timeout   int64  `protobuf:"varint,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Created   int64  `protobuf:"varint,5,opt,name=created,proto3" json:"created,omitempty"`
+	Answered  int64  `protobuf:"varint,6,opt,name=answered,proto3" json:"answered,omitempty"`
+	RobotDone int64  `protobuf:"varint,7,opt,name=robot_done,json=robotDone,proto3" json:"robot_done,omitempty"`
+	UserDone  int64  `protobuf:"varint,8,opt,name=user_done,json=userDone,proto3" json:"user_done,omitempty"`
+	Cancelled int64  `protobuf:"varint,9,opt,name=cancelled,proto3" json:"cancelled,omitempty"`
+}
+
+func (x *SwapLifecycle) Reset() {
+	*x = SwapLifecycle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapLifecycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapLifecycle) ProtoMessage() {}
+
+func (x *SwapLifecycle) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapLifecycle.ProtoReflect.Descriptor instead.
+func (*SwapLifecycle) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SwapLifecycle) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SwapLifecycle) GetMulti() bool {
+	if x != nil {
+		return x.Multi
+	}
+	return false
+}
+
+func (x *SwapLifecycle) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SwapLifecycle) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *SwapLifecycle) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *SwapLifecycle) GetAnswered() int64 {
+	if x != nil {
+		return x.Answered
+	}
+	return 0
+}
+
+func (x *SwapLifecycle) GetRobotDone() int64 {
+	if x != nil {
+		return x.RobotDone
+	}
+	return 0
+}
+
+func (x *SwapLifecycle) GetUserDone() int64 {
+	if x != nil {
+		return x.UserDone
+	}
+	return 0
+}
+
+func (x *SwapLifecycle) GetCancelled() int64 {
+	if x != nil {
+		return x.Cancelled
+	}
+	return 0
+}
+
+type SwapEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id            []byte   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Multi         bool     `protobuf:"varint,2,opt,name=multi,proto3" json:"multi,omitempty"`
+	Creator       []byte   `protobuf:"bytes,3,opt,name=creator,proto3" json:"creator,omitempty"`
+	Owner         []byte   `protobuf:"bytes,4,opt,name=owner,proto3" json:"owner,omitempty"`
+	Token         string   `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	Amount        []byte   `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Assets        []*Asset `protobuf:"bytes,7,rep,name=assets,proto3" json:"assets,omitempty"`
+	From          string   `protobuf:"bytes,8,opt,name=from,proto3" json:"from,omitempty"`
+	To            string   `protobuf:"bytes,9,opt,name=to,proto3" json:"to,omitempty"`
+	Hash          []byte   `protobuf:"bytes,10,opt,name=hash,proto3" json:"hash,omitempty"`
+	Timeout       int64    `protobuf:"varint,11,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Key           string   `protobuf:"bytes,12,opt,name=key,proto3" json:"key,omitempty"`
+	Route         []string `protobuf:"bytes,13,rep,name=route,proto3" json:"route,omitempty"`
+	ForwardedFrom string   `protobuf:"bytes,14,opt,name=forwarded_from,json=forwardedFrom,proto3" json:"forwarded_from,omitempty"`
+}
+
+func (x *SwapEvent) Reset() {
+	*x = SwapEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapEvent) ProtoMessage() {}
+
+func (x *SwapEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapEvent.ProtoReflect.Descriptor instead.
+func (*SwapEvent) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SwapEvent) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *SwapEvent) GetMulti() bool {
+	if x != nil {
+		return x.Multi
+	}
+	return false
+}
+
+func (x *SwapEvent) GetCreator() []byte {
+	if x != nil {
+		return x.Creator
+	}
+	return nil
+}
+
+func (x *SwapEvent) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *SwapEvent) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetAmount() []byte {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *SwapEvent) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *SwapEvent) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetHash() []byte {
+	if x != nil {
+		return x.Hash
+	}
+	return nil
+}
+
+func (x *SwapEvent) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+func (x *SwapEvent) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SwapEvent) GetRoute() []string {
+	if x != nil {
+		return x.Route
+	}
+	return nil
+}
+
+func (x *SwapEvent) GetForwardedFrom() string {
+	if x != nil {
+		return x.ForwardedFrom
+	}
+	return ""
+}
+
+type DvpLeg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token  string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Amount []byte `protobuf:"bytes,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *DvpLeg) Reset() {
+	*x = DvpLeg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DvpLeg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DvpLeg) ProtoMessage() {}
+
+func (x *DvpLeg) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DvpLeg.ProtoReflect.Descriptor instead.
+func (*DvpLeg) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *DvpLeg) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *DvpLeg) GetAmount() []byte {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type Dvp struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       []byte  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Maker    []byte  `protobuf:"bytes,2,opt,name=maker,proto3" json:"maker,omitempty"`
+	Taker    []byte  `protobuf:"bytes,3,opt,name=taker,proto3" json:"taker,omitempty"`
+	MakerLeg *DvpLeg `protobuf:"bytes,4,opt,name=maker_leg,json=makerLeg,proto3" json:"maker_leg,omitempty"`
+	TakerLeg *DvpLeg `protobuf:"bytes,5,opt,name=taker_leg,json=takerLeg,proto3" json:"taker_leg,omitempty"`
+	Timeout  int64   `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`
+}
+
+func (x *Dvp) Reset() {
+	*x = Dvp{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Dvp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Dvp) ProtoMessage() {}
+
+func (x *Dvp) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Dvp.ProtoReflect.Descriptor instead.
+func (*Dvp) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Dvp) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Dvp) GetMaker() []byte {
+	if x != nil {
+		return x.Maker
+	}
+	return nil
+}
+
+func (x *Dvp) GetTaker() []byte {
+	if x != nil {
+		return x.Taker
+	}
+	return nil
+}
+
+func (x *Dvp) GetMakerLeg() *DvpLeg {
+	if x != nil {
+		return x.MakerLeg
+	}
+	return nil
+}
+
+func (x *Dvp) GetTakerLeg() *DvpLeg {
+	if x != nil {
+		return x.TakerLeg
+	}
+	return nil
+}
+
+func (x *Dvp) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+type Vesting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Beneficiary []byte `protobuf:"bytes,2,opt,name=beneficiary,proto3" json:"beneficiary,omitempty"`
+	Amount      []byte `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Claimed     []byte `protobuf:"bytes,4,opt,name=claimed,proto3" json:"claimed,omitempty"`
+	Start       int64  `protobuf:"varint,5,opt,name=start,proto3" json:"start,omitempty"`
+	Cliff       int64  `protobuf:"varint,6,opt,name=cliff,proto3" json:"cliff,omitempty"`
+	Duration    int64  `protobuf:"varint,7,opt,name=duration,proto3" json:"duration,omitempty"`
+	Step        int64  `protobuf:"varint,8,opt,name=step,proto3" json:"step,omitempty"`
+}
+
+func (x *Vesting) Reset() {
+	*x = Vesting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Vesting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Vesting) ProtoMessage() {}
+
+func (x *Vesting) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Vesting.ProtoReflect.Descriptor instead.
+func (*Vesting) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *Vesting) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Vesting) GetBeneficiary() []byte {
+	if x != nil {
+		return x.Beneficiary
+	}
+	return nil
+}
+
+func (x *Vesting) GetAmount() []byte {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Vesting) GetClaimed() []byte {
+	if x != nil {
+		return x.Claimed
+	}
+	return nil
+}
+
+func (x *Vesting) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Vesting) GetCliff() int64 {
+	if x != nil {
+		return x.Cliff
+	}
+	return 0
+}
+
+func (x *Vesting) GetDuration() int64 {
+	if x != nil {
+		return x.Duration
+	}
+	return 0
+}
+
+func (x *Vesting) GetStep() int64 {
+	if x != nil {
+		return x.Step
+	}
+	return 0
+}
+
+type Hold struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Owner       []byte `protobuf:"bytes,2,opt,name=owner,proto3" json:"owner,omitempty"`
+	Token       string `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Amount      []byte `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Beneficiary []byte `protobuf:"bytes,5,opt,name=beneficiary,proto3" json:"beneficiary,omitempty"`
+	Expiry      int64  `protobuf:"varint,6,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Reason      string `protobuf:"bytes,7,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *Hold) Reset() {
+	*x = Hold{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Hold) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Hold) ProtoMessage() {}
+
+func (x *Hold) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Hold.ProtoReflect.Descriptor instead.
+func (*Hold) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Hold) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Hold) GetOwner() []byte {
+	if x != nil {
+		return x.Owner
+	}
+	return nil
+}
+
+func (x *Hold) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Hold) GetAmount() []byte {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *Hold) GetBeneficiary() []byte {
+	if x != nil {
+		return x.Beneficiary
+	}
+	return nil
+}
+
+func (x *Hold) GetExpiry() int64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+func (x *Hold) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type Freeze struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Incoming bool   `protobuf:"varint,1,opt,name=incoming,proto3" json:"incoming,omitempty"`
+	Reason   string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *Freeze) Reset() {
+	*x = Freeze{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_batch_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Freeze) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Freeze) ProtoMessage() {}
+
+func (x *Freeze) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Freeze.ProtoReflect.Descriptor instead.
+func (*Freeze) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *Freeze) GetIncoming() bool {
+	if x != nil {
+		return x.Incoming
+	}
+	return false
+}
+
+func (x *Freeze) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RegulatorEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action    string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	Sender    []byte `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`
+	Address   []byte `protobuf:"bytes,3,opt,name=address,proto3" json:"address,omitempty"`
+	Recipient []byte `protobuf:"bytes,4,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	Token     string `protobuf:"bytes,5,opt,name=token,proto3" json:"token,omitempty"`
+	Amount    []byte `protobuf:"bytes,6,opt,name=amount,proto3" json:"amount,omitempty"`
+	Incoming  bool   `protobuf:"varint,7,opt,name=incoming,proto3" json:"incoming,omitempty"`
+	Reason    string `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
 }
 
-func (x *PendingTx) Reset() {
-	*x = PendingTx{}
+func (x *RegulatorEvent) Reset() {
+	*x = RegulatorEvent{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_batch_proto_msgTypes[33]
+		mi := &file_batch_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *PendingTx) String() string {
+func (x *RegulatorEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PendingTx) ProtoMessage() {}
+func (*RegulatorEvent) ProtoMessage() {}
 
-func (x *PendingTx) ProtoReflect() protoreflect.Message {
-	mi := &file_batch_proto_msgTypes[33]
+func (x *RegulatorEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_batch_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2275,51 +3638,65 @@ func (x *PendingTx) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PendingTx.ProtoReflect.Descriptor instead.
-func (*PendingTx) Descriptor() ([]byte, []int) {
-	return file_batch_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use RegulatorEvent.ProtoReflect.Descriptor instead.
+func (*RegulatorEvent) Descriptor() ([]byte, []int) {
+	return file_batch_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *PendingTx) GetMethod() string {
+func (x *RegulatorEvent) GetAction() string {
 	if x != nil {
-		return x.Method
+		return x.Action
 	}
 	return ""
 }
 
-func (x *PendingTx) GetSender() *Address {
+func (x *RegulatorEvent) GetSender() []byte {
 	if x != nil {
 		return x.Sender
 	}
 	return nil
 }
 
-func (x *PendingTx) GetArgs() []string {
+func (x *RegulatorEvent) GetAddress() []byte {
 	if x != nil {
-		return x.Args
+		return x.Address
 	}
 	return nil
 }
 
-func (x *PendingTx) GetCreatorSKI() []byte {
+func (x *RegulatorEvent) GetRecipient() []byte {
 	if x != nil {
-		return x.CreatorSKI
+		return x.Recipient
 	}
 	return nil
 }
 
-func (x *PendingTx) GetTimestamp() int64 {
+func (x *RegulatorEvent) GetToken() string {
 	if x != nil {
-		return x.Timestamp
+		return x.Token
 	}
-	return 0
+	return ""
 }
 
-func (x *PendingTx) GetNonce() uint64 {
+func (x *RegulatorEvent) GetAmount() []byte {
 	if x != nil {
-		return x.Nonce
+		return x.Amount
 	}
-	return 0
+	return nil
+}
+
+func (x *RegulatorEvent) GetIncoming() bool {
+	if x != nil {
+		return x.Incoming
+	}
+	return false
+}
+
+func (x *RegulatorEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
 var File_batch_proto protoreflect.FileDescriptor
@@ -2343,7 +3720,7 @@ var file_batch_proto_rawDesc = []byte{
 	0x22, 0x35, 0x0a, 0x05, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f,
 	0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
 	0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xc6, 0x01, 0x0a, 0x04, 0x53, 0x77, 0x61, 0x70,
+	0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xe6, 0x02, 0x0a, 0x04, 0x53, 0x77, 0x61, 0x70,
 	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64,
 	0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x0c, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77,
@@ -2356,9 +3733,23 @@ var file_batch_proto_rawDesc = []byte{
 	0x74, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0c,
 	0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
 	0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x66,
+	0x65, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
+	0x63, 0x79, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x65, 0x65, 0x43, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x0d,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x46, 0x72,
+	0x6f, 0x6d, 0x12, 0x2c, 0x0a, 0x08, 0x66, 0x65, 0x65, 0x5f, 0x6c, 0x65, 0x67, 0x73, 0x18, 0x0f,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x77, 0x61,
+	0x70, 0x46, 0x65, 0x65, 0x4c, 0x65, 0x67, 0x52, 0x07, 0x66, 0x65, 0x65, 0x4c, 0x65, 0x67, 0x73,
+	0x22, 0x3e, 0x0a, 0x0a, 0x53, 0x77, 0x61, 0x70, 0x46, 0x65, 0x65, 0x4c, 0x65, 0x67, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
 	0x22, 0x2b, 0x0a, 0x07, 0x53, 0x77, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x12, 0x0e, 0x0a, 0x02, 0x69,
 	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xd1, 0x01,
+	0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x81, 0x02,
 	0x0a, 0x05, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x78, 0x49, 0x44, 0x73,
 	0x18, 0x01, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05, 0x74, 0x78, 0x49, 0x44, 0x73, 0x12, 0x21, 0x0a,
 	0x05, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70,
@@ -2372,6 +3763,9 @@ var file_batch_proto_rawDesc = []byte{
 	0x0a, 0x0b, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x05, 0x20,
 	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x6c, 0x74,
 	0x69, 0x53, 0x77, 0x61, 0x70, 0x52, 0x0a, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x77, 0x61, 0x70,
+	0x73, 0x12, 0x2e, 0x0a, 0x13, 0x73, 0x77, 0x65, 0x65, 0x70, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72,
+	0x65, 0x64, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x11,
+	0x73, 0x77, 0x65, 0x65, 0x70, 0x45, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70,
 	0x73, 0x22, 0x5a, 0x0a, 0x08, 0x49, 0x6e, 0x69, 0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x1e, 0x0a,
 	0x0a, 0x61, 0x74, 0x6f, 0x6d, 0x79, 0x7a, 0x65, 0x53, 0x4b, 0x49, 0x18, 0x01, 0x20, 0x01, 0x28,
 	0x0c, 0x52, 0x0a, 0x61, 0x74, 0x6f, 0x6d, 0x79, 0x7a, 0x65, 0x53, 0x4b, 0x49, 0x12, 0x1a, 0x0a,
@@ -2387,212 +3781,386 @@ var file_batch_proto_rawDesc = []byte{
 	0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20,
 	0x01, 0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72,
 	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22,
-	0x77, 0x0a, 0x0c, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
-	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45,
-	0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x06, 0x77,
-	0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74,
-	0x52, 0x06, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x10, 0x41, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x14, 0x0a,
-	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
-	0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72,
-	0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
-	0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f,
-	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x31, 0x0a, 0x05, 0x45, 0x76, 0x65,
-	0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x8d, 0x01, 0x0a,
-	0x0a, 0x54, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d,
-	0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12,
-	0x2b, 0x0a, 0x06, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x45, 0x6c, 0x65,
-	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x22, 0xd9, 0x01, 0x0a,
-	0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x54, 0x78, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a,
-	0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d,
-	0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
-	0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12,
-	0x37, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x63, 0x6f,
-	0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x52, 0x0a, 0x61, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x39, 0x0a, 0x0a, 0x42, 0x61, 0x74, 0x63,
-	0x68, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42,
-	0x61, 0x74, 0x63, 0x68, 0x54, 0x78, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65,
-	0x6e, 0x74, 0x73, 0x22, 0xb6, 0x02, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34, 0x0a, 0x0c, 0x74, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0b,
-	0x74, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x0d, 0x63,
-	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52,
-	0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x3a, 0x0a,
-	0x0e, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x77,
-	0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0d, 0x73, 0x77, 0x61, 0x70,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x12, 0x73, 0x77, 0x61,
-	0x70, 0x5f, 0x6b, 0x65, 0x79, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18,
-	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x77,
-	0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x10, 0x73, 0x77, 0x61, 0x70,
-	0x4b, 0x65, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x12,
-	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x73, 0x77,
-	0x61, 0x70, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x77, 0x61, 0x70, 0x52, 0x10, 0x63, 0x72, 0x65, 0x61,
-	0x74, 0x65, 0x64, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x77, 0x61, 0x70, 0x22, 0x1c, 0x0a, 0x06,
-	0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x01,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x22, 0x60, 0x0a, 0x08, 0x54, 0x6f,
-	0x6b, 0x65, 0x6e, 0x46, 0x65, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e,
-	0x63, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x03, 0x66, 0x65, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x6f, 0x6f, 0x72, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x05, 0x66, 0x6c, 0x6f, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x61,
-	0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x63, 0x61, 0x70, 0x22, 0xa4, 0x01, 0x0a,
-	0x09, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x65,
-	0x61, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64,
-	0x65, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65,
-	0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65,
-	0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x0c, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78,
-	0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12, 0x26, 0x0a, 0x06, 0x69,
-	0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x69, 0x73, 0x73,
-	0x75, 0x65, 0x72, 0x22, 0x9a, 0x01, 0x0a, 0x05, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x25, 0x0a,
-	0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x45, 0x6d, 0x69, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46,
-	0x65, 0x65, 0x52, 0x03, 0x66, 0x65, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73,
-	0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54,
-	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x12,
-	0x1f, 0x0a, 0x0b, 0x66, 0x65, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x66, 0x65, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x22, 0x29, 0x0a, 0x09, 0x48, 0x61, 0x76, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a,
-	0x09, 0x68, 0x61, 0x76, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
-	0x52, 0x09, 0x68, 0x61, 0x76, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x22, 0xeb, 0x01, 0x0a, 0x05,
-	0x52, 0x69, 0x67, 0x68, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c,
-	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e,
-	0x6e, 0x65, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x68, 0x61, 0x69, 0x6e,
-	0x63, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
-	0x63, 0x68, 0x61, 0x69, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a,
-	0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x6f, 0x70, 0x65,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12,
-	0x28, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2e, 0x0a, 0x09, 0x68, 0x61, 0x76,
-	0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x61, 0x76, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x52, 0x09,
-	0x68, 0x61, 0x76, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x22, 0x5f, 0x0a, 0x0d, 0x41, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x28, 0x0a, 0x07, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x69, 0x67,
-	0x68, 0x74, 0x52, 0x06, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x22, 0x38, 0x0a, 0x08, 0x41, 0x63,
-	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x65, 0x73, 0x22, 0x2c, 0x0a, 0x0a, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x22, 0x5d, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
-	0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x70,
-	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a, 0x06, 0x72,
-	0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x52, 0x06, 0x72, 0x69, 0x67, 0x68, 0x74,
-	0x73, 0x22, 0xca, 0x01, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x69, 0x61, 0x6c,
-	0x12, 0x2e, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
-	0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72,
-	0x69, 0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
-	0x12, 0x21, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x65, 0x65, 0x52, 0x03,
-	0x66, 0x65, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
-	0x52, 0x61, 0x74, 0x65, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66,
-	0x65, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0a, 0x66, 0x65, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b,
-	0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x0b, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x22, 0x6d,
-	0x0a, 0x0f, 0x49, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x69, 0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75,
-	0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
-	0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a,
-	0x08, 0x6d, 0x61, 0x74, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x08, 0x6d, 0x61, 0x74, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74,
-	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x22, 0x69, 0x0a,
-	0x0b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07,
-	0x6b, 0x79, 0x63, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6b,
-	0x79, 0x63, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x72, 0x61, 0x79, 0x4c, 0x69,
-	0x73, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x67, 0x72, 0x61, 0x79,
-	0x4c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x4c,
-	0x69, 0x73, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x62, 0x6c, 0x61,
-	0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x22, 0x7f, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x44, 0x12, 0x18, 0x0a, 0x07, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x73, 0x49, 0x6e, 0x64, 0x75, 0x73,
-	0x74, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x69, 0x73, 0x49,
-	0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x73, 0x4d,
-	0x75, 0x6c, 0x74, 0x69, 0x73, 0x69, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x69,
-	0x73, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x73, 0x69, 0x67, 0x22, 0xcb, 0x01, 0x0a, 0x0d, 0x53, 0x69,
-	0x67, 0x6e, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x28, 0x0a, 0x07, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54,
-	0x78, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54,
-	0x78, 0x12, 0x40, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x6f,
-	0x6c, 0x69, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69,
-	0x63, 0x79, 0x52, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c,
-	0x69, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72,
-	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x6b, 0x0a, 0x0f, 0x53, 0x69, 0x67, 0x6e, 0x61,
-	0x74, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x75, 0x62, 0x4b,
-	0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65,
-	0x79, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x4b, 0x65, 0x79,
-	0x73, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x78, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x13, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x53, 0x69, 0x67, 0x6e,
-	0x65, 0x64, 0x54, 0x78, 0x22, 0x6b, 0x0a, 0x0b, 0x41, 0x63, 0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x63,
-	0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
-	0x74, 0x12, 0x2e, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65,
-	0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
-	0x73, 0x22, 0x1d, 0x0a, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f,
-	0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65,
-	0x22, 0xb3, 0x01, 0x0a, 0x09, 0x70, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x54, 0x78, 0x12, 0x16,
-	0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x26, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x12,
-	0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72,
-	0x67, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x4b, 0x49,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72, 0x53,
-	0x4b, 0x49, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
-	0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
-	0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
-	0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x3b, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x9d, 0x01, 0x0a, 0x0c, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x06,
+	0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e,
+	0x74, 0x52, 0x06, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0xb3, 0x01, 0x0a, 0x0c, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x06, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65,
+	0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73, 0x12,
+	0x24, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xdd, 0x01, 0x0a, 0x10, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69,
+	0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x63,
+	0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x65,
+	0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x12, 0x10, 0x0a, 0x03, 0x72, 0x65, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72,
+	0x65, 0x66, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x61, 0x74, 0x65, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x79, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x52, 0x65, 0x66, 0x12, 0x13, 0x0a, 0x05, 0x74, 0x78, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x49, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x37, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x63, 0x6f, 0x72, 0x64, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67,
+	0x22, 0x31, 0x0a, 0x05, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x22, 0x8d, 0x01, 0x0a, 0x0a, 0x54, 0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x2a, 0x0a, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x2b, 0x0a, 0x06, 0x77, 0x72, 0x69, 0x74, 0x65, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57,
+	0x72, 0x69, 0x74, 0x65, 0x45, 0x6c, 0x65, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x77, 0x72, 0x69,
+	0x74, 0x65, 0x73, 0x22, 0xed, 0x01, 0x0a, 0x0c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x54, 0x78, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x2a, 0x0a, 0x05,
+	0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x12, 0x24, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06,
+	0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x52, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x12, 0x0a, 0x04, 0x72, 0x65, 0x66, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x72,
+	0x65, 0x66, 0x73, 0x22, 0x39, 0x0a, 0x0a, 0x42, 0x61, 0x74, 0x63, 0x68, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x2b, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x54,
+	0x78, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0xc4,
+	0x03, 0x0a, 0x0d, 0x42, 0x61, 0x74, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x34, 0x0a, 0x0c, 0x74, 0x78, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54,
+	0x78, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0b, 0x74, 0x78, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x3a, 0x0a, 0x0e, 0x73, 0x77, 0x61, 0x70,
+	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x0d, 0x73, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x73, 0x12, 0x41, 0x0a, 0x12, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x6b, 0x65, 0x79,
+	0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x10, 0x73, 0x77, 0x61, 0x70, 0x4b, 0x65, 0x79, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x12, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4d, 0x75, 0x6c, 0x74,
+	0x69, 0x53, 0x77, 0x61, 0x70, 0x52, 0x10, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x75,
+	0x6c, 0x74, 0x69, 0x53, 0x77, 0x61, 0x70, 0x12, 0x45, 0x0a, 0x14, 0x73, 0x77, 0x65, 0x70, 0x74,
+	0x5f, 0x73, 0x77, 0x61, 0x70, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x77,
+	0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x12, 0x73, 0x77, 0x65, 0x70,
+	0x74, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x12, 0x45,
+	0x0a, 0x14, 0x73, 0x77, 0x65, 0x70, 0x74, 0x5f, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x52, 0x12, 0x73, 0x77, 0x65, 0x70, 0x74, 0x48, 0x6f, 0x6c, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x73, 0x22, 0x1c, 0x0a, 0x06, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61,
+	0x72, 0x67, 0x73, 0x22, 0x8b, 0x01, 0x0a, 0x08, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x65, 0x65,
+	0x12, 0x1a, 0x0a, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x66, 0x65, 0x65, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x6c, 0x6f, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x66,
+	0x6c, 0x6f, 0x6f, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x61, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x03, 0x63, 0x61, 0x70, 0x12, 0x29, 0x0a, 0x05, 0x74, 0x69, 0x65, 0x72, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x46, 0x65, 0x65, 0x54, 0x69, 0x65, 0x72, 0x52, 0x05, 0x74, 0x69, 0x65, 0x72,
+	0x73, 0x22, 0x34, 0x0a, 0x0c, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x65, 0x65, 0x54, 0x69, 0x65,
+	0x72, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x10, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x03, 0x66, 0x65, 0x65, 0x22, 0x47, 0x0a, 0x08, 0x46, 0x65, 0x65, 0x53, 0x70,
+	0x6c, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x21, 0x0a,
+	0x0c, 0x62, 0x61, 0x73, 0x69, 0x73, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x0b, 0x62, 0x61, 0x73, 0x69, 0x73, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x22, 0xf8, 0x01, 0x0a, 0x09, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x64, 0x65, 0x61, 0x6c, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x64, 0x65, 0x61, 0x6c, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x6d,
+	0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a,
+	0x03, 0x6d, 0x61, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x12,
+	0x26, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x61, 0x6c, 0x69, 0x64,
+	0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x76, 0x61, 0x6c,
+	0x69, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x19, 0x0a, 0x08, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x5f,
+	0x74, 0x6f, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x54,
+	0x6f, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x6e, 0x0a, 0x10, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12,
+	0x24, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x52,
+	0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74,
+	0x61, 0x6d, 0x70, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xb8, 0x02, 0x0a, 0x05,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x65,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74,
+	0x6f, 0x74, 0x61, 0x6c, 0x45, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x03,
+	0x66, 0x65, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x65, 0x65, 0x52, 0x03, 0x66, 0x65, 0x65, 0x12,
+	0x26, 0x0a, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x61, 0x74, 0x65,
+	0x52, 0x05, 0x72, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x65, 0x65, 0x5f, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x66, 0x65,
+	0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2a, 0x0a, 0x08, 0x73, 0x77, 0x61, 0x70,
+	0x5f, 0x66, 0x65, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x46, 0x65, 0x65, 0x52, 0x07, 0x73, 0x77, 0x61,
+	0x70, 0x46, 0x65, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x75, 0x70, 0x70,
+	0x6c, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x53, 0x75, 0x70,
+	0x70, 0x6c, 0x79, 0x12, 0x2e, 0x0a, 0x0a, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x70, 0x6c, 0x69, 0x74,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x46, 0x65, 0x65, 0x53, 0x70, 0x6c, 0x69, 0x74, 0x52, 0x09, 0x66, 0x65, 0x65, 0x53, 0x70, 0x6c,
+	0x69, 0x74, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x72, 0x61, 0x74, 0x65, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x29, 0x0a, 0x09, 0x48, 0x61, 0x76, 0x65, 0x52, 0x69,
+	0x67, 0x68, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x68, 0x61, 0x76, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x68, 0x61, 0x76, 0x65, 0x52, 0x69, 0x67, 0x68,
+	0x74, 0x22, 0xeb, 0x01, 0x0a, 0x05, 0x52, 0x69, 0x67, 0x68, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12,
+	0x24, 0x0a, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x28, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x2e, 0x0a, 0x09, 0x68, 0x61, 0x76, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x48, 0x61, 0x76, 0x65, 0x52,
+	0x69, 0x67, 0x68, 0x74, 0x52, 0x09, 0x68, 0x61, 0x76, 0x65, 0x52, 0x69, 0x67, 0x68, 0x74, 0x22,
+	0x5f, 0x0a, 0x0d, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73,
+	0x12, 0x28, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x06, 0x72, 0x69,
+	0x67, 0x68, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x52, 0x06, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73,
+	0x22, 0x38, 0x0a, 0x08, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x09,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x2c, 0x0a, 0x0a, 0x4f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x5d, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0d, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x24, 0x0a, 0x06, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x52, 0x69, 0x67, 0x68, 0x74, 0x52,
+	0x06, 0x72, 0x69, 0x67, 0x68, 0x74, 0x73, 0x22, 0xca, 0x01, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x75,
+	0x73, 0x74, 0x72, 0x69, 0x61, 0x6c, 0x12, 0x2e, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x49,
+	0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x69, 0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x21, 0x0a, 0x03, 0x66, 0x65, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x46, 0x65, 0x65, 0x52, 0x03, 0x66, 0x65, 0x65, 0x12, 0x26, 0x0a, 0x05, 0x72, 0x61, 0x74,
+	0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x52, 0x61, 0x74, 0x65, 0x52, 0x05, 0x72, 0x61, 0x74, 0x65,
+	0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x66, 0x65, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x66, 0x65, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x69, 0x7a, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c,
+	0x69, 0x7a, 0x65, 0x64, 0x22, 0x6d, 0x0a, 0x0f, 0x49, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x69,
+	0x61, 0x6c, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x65, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x74, 0x75, 0x72, 0x69, 0x74, 0x79, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6d, 0x61, 0x74, 0x75, 0x72, 0x69, 0x74, 0x79, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x6f, 0x74, 0x65, 0x22, 0x69, 0x0a, 0x0b, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x6b, 0x79, 0x63, 0x48, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x6b, 0x79, 0x63, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1e, 0x0a, 0x0a,
+	0x67, 0x72, 0x61, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x67, 0x72, 0x61, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x12, 0x20, 0x0a, 0x0b,
+	0x62, 0x6c, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0b, 0x62, 0x6c, 0x61, 0x63, 0x6b, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x64, 0x22, 0x7f,
+	0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
+	0x44, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x69,
+	0x73, 0x49, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x0c, 0x69, 0x73, 0x49, 0x6e, 0x64, 0x75, 0x73, 0x74, 0x72, 0x69, 0x61, 0x6c, 0x12,
+	0x1e, 0x0a, 0x0a, 0x69, 0x73, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x73, 0x69, 0x67, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0a, 0x69, 0x73, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x73, 0x69, 0x67, 0x22,
+	0xcb, 0x01, 0x0a, 0x0d, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x28, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x78, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73,
+	0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x78, 0x12, 0x40, 0x0a, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61,
+	0x73, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x64, 0x22, 0x6b, 0x0a,
+	0x0f, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x0c, 0x0a, 0x01, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x01, 0x6e, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0c, 0x52,
+	0x07, 0x70, 0x75, 0x62, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x72, 0x65, 0x70, 0x6c,
+	0x61, 0x63, 0x65, 0x4b, 0x65, 0x79, 0x73, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x78, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x4b, 0x65,
+	0x79, 0x73, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x54, 0x78, 0x22, 0x6b, 0x0a, 0x0b, 0x41, 0x63,
+	0x6c, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x07, 0x61, 0x63, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x41, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07,
+	0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x1d, 0x0a, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x03, 0x28, 0x04, 0x52,
+	0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x22, 0xf3, 0x01, 0x0a, 0x09, 0x70, 0x65, 0x6e, 0x64, 0x69,
+	0x6e, 0x67, 0x54, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x26, 0x0a, 0x06,
+	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x6f, 0x72, 0x53, 0x4b, 0x49, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x6f, 0x72, 0x53, 0x4b, 0x49, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x22, 0x0a, 0x05, 0x72, 0x65, 0x6c, 0x61,
+	0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e,
+	0x52, 0x65, 0x6c, 0x61, 0x79, 0x52, 0x05, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x22, 0x6a, 0x0a, 0x05,
+	0x52, 0x65, 0x6c, 0x61, 0x79, 0x12, 0x28, 0x0a, 0x07, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x72, 0x65, 0x6c, 0x61, 0x79, 0x65, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05,
+	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x65, 0x65, 0x5f, 0x63, 0x75, 0x72,
+	0x72, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x66, 0x65, 0x65,
+	0x43, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x22, 0xf7, 0x01, 0x0a, 0x0d, 0x53, 0x77, 0x61,
+	0x70, 0x4c, 0x69, 0x66, 0x65, 0x63, 0x79, 0x63, 0x6c, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x75,
+	0x6c, 0x74, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x6d, 0x75, 0x6c, 0x74, 0x69,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65,
+	0x6f, 0x75, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f,
+	0x75, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f, 0x62, 0x6f,
+	0x74, 0x5f, 0x64, 0x6f, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x72, 0x6f,
+	0x62, 0x6f, 0x74, 0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x72, 0x5f,
+	0x64, 0x6f, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x73, 0x65, 0x72,
+	0x44, 0x6f, 0x6e, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c, 0x65,
+	0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x61, 0x6e, 0x63, 0x65, 0x6c, 0x6c,
+	0x65, 0x64, 0x22, 0xd6, 0x02, 0x0a, 0x09, 0x53, 0x77, 0x61, 0x70, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f,
+	0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x63, 0x72, 0x65, 0x61, 0x74, 0x6f, 0x72,
+	0x12, 0x14, 0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x24, 0x0a, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x18, 0x07,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x73, 0x73,
+	0x65, 0x74, 0x52, 0x06, 0x61, 0x73, 0x73, 0x65, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e,
+	0x0a, 0x02, 0x74, 0x6f, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x12,
+	0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x68, 0x61,
+	0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x65,
+	0x64, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x66, 0x6f,
+	0x72, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x22, 0x36, 0x0a, 0x06, 0x44,
+	0x76, 0x70, 0x4c, 0x65, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0xb3, 0x01, 0x0a, 0x03, 0x44, 0x76, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6d,
+	0x61, 0x6b, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6d, 0x61, 0x6b, 0x65,
+	0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x05, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x09, 0x6d, 0x61, 0x6b, 0x65, 0x72,
+	0x5f, 0x6c, 0x65, 0x67, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x44, 0x76, 0x70, 0x4c, 0x65, 0x67, 0x52, 0x08, 0x6d, 0x61, 0x6b, 0x65, 0x72,
+	0x4c, 0x65, 0x67, 0x12, 0x2a, 0x0a, 0x09, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x5f, 0x6c, 0x65, 0x67,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44,
+	0x76, 0x70, 0x4c, 0x65, 0x67, 0x52, 0x08, 0x74, 0x61, 0x6b, 0x65, 0x72, 0x4c, 0x65, 0x67, 0x12,
+	0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x22, 0xc9, 0x01, 0x0a, 0x07, 0x56, 0x65,
+	0x73, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x65, 0x6e, 0x65, 0x66, 0x69, 0x63,
+	0x69, 0x61, 0x72, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x62, 0x65, 0x6e, 0x65,
+	0x66, 0x69, 0x63, 0x69, 0x61, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x07, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6c, 0x69, 0x66, 0x66, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x6c, 0x69, 0x66, 0x66, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x74, 0x65, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x04, 0x73, 0x74, 0x65, 0x70, 0x22, 0xac, 0x01, 0x0a, 0x04, 0x48, 0x6f, 0x6c, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x65, 0x6e, 0x65, 0x66, 0x69, 0x63, 0x69, 0x61, 0x72,
+	0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x62, 0x65, 0x6e, 0x65, 0x66, 0x69, 0x63,
+	0x69, 0x61, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x22, 0x3c, 0x0a, 0x06, 0x46, 0x72, 0x65, 0x65, 0x7a, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x08, 0x69, 0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x22, 0xda, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x67, 0x75, 0x6c, 0x61, 0x74, 0x6f, 0x72,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73,
+	0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x69,
+	0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x69,
+	0x6e, 0x63, 0x6f, 0x6d, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f,
+	0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x42,
+	0x0a, 0x5a, 0x08, 0x2e, 0x2f, 0x3b, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -2607,84 +4175,116 @@ func file_batch_proto_rawDescGZIP() []byte {
 	return file_batch_proto_rawDescData
 }
 
-var file_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_batch_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_batch_proto_goTypes = []interface{}{
 	(*MultiSwap)(nil),        // 0: proto.MultiSwap
 	(*Asset)(nil),            // 1: proto.Asset
 	(*Swap)(nil),             // 2: proto.Swap
-	(*SwapKey)(nil),          // 3: proto.SwapKey
-	(*Batch)(nil),            // 4: proto.Batch
-	(*InitArgs)(nil),         // 5: proto.InitArgs
-	(*WriteElement)(nil),     // 6: proto.WriteElement
-	(*ResponseError)(nil),    // 7: proto.ResponseError
-	(*SwapResponse)(nil),     // 8: proto.SwapResponse
-	(*AccountingRecord)(nil), // 9: proto.AccountingRecord
-	(*Event)(nil),            // 10: proto.Event
-	(*TxResponse)(nil),       // 11: proto.TxResponse
-	(*BatchTxEvent)(nil),     // 12: proto.BatchTxEvent
-	(*BatchEvent)(nil),       // 13: proto.BatchEvent
-	(*BatchResponse)(nil),    // 14: proto.BatchResponse
-	(*Nested)(nil),           // 15: proto.Nested
-	(*TokenFee)(nil),         // 16: proto.TokenFee
-	(*TokenRate)(nil),        // 17: proto.TokenRate
-	(*Token)(nil),            // 18: proto.Token
-	(*HaveRight)(nil),        // 19: proto.HaveRight
-	(*Right)(nil),            // 20: proto.Right
-	(*AccountRights)(nil),    // 21: proto.AccountRights
-	(*Accounts)(nil),         // 22: proto.Accounts
-	(*Operations)(nil),       // 23: proto.Operations
-	(*OperationRights)(nil),  // 24: proto.OperationRights
-	(*Industrial)(nil),       // 25: proto.Industrial
-	(*IndustrialGroup)(nil),  // 26: proto.IndustrialGroup
-	(*AccountInfo)(nil),      // 27: proto.AccountInfo
-	(*Address)(nil),          // 28: proto.Address
-	(*SignedAddress)(nil),    // 29: proto.SignedAddress
-	(*SignaturePolicy)(nil),  // 30: proto.SignaturePolicy
-	(*AclResponse)(nil),      // 31: proto.AclResponse
-	(*Nonce)(nil),            // 32: proto.Nonce
-	(*PendingTx)(nil),        // 33: proto.pendingTx
+	(*SwapFeeLeg)(nil),       // 3: proto.SwapFeeLeg
+	(*SwapKey)(nil),          // 4: proto.SwapKey
+	(*Batch)(nil),            // 5: proto.Batch
+	(*InitArgs)(nil),         // 6: proto.InitArgs
+	(*WriteElement)(nil),     // 7: proto.WriteElement
+	(*ResponseError)(nil),    // 8: proto.ResponseError
+	(*SwapResponse)(nil),     // 9: proto.SwapResponse
+	(*HoldResponse)(nil),     // 10: proto.HoldResponse
+	(*AccountingRecord)(nil), // 11: proto.AccountingRecord
+	(*TransferRef)(nil),      // 12: proto.TransferRef
+	(*Event)(nil),            // 13: proto.Event
+	(*TxResponse)(nil),       // 14: proto.TxResponse
+	(*BatchTxEvent)(nil),     // 15: proto.BatchTxEvent
+	(*BatchEvent)(nil),       // 16: proto.BatchEvent
+	(*BatchResponse)(nil),    // 17: proto.BatchResponse
+	(*Nested)(nil),           // 18: proto.Nested
+	(*TokenFee)(nil),         // 19: proto.TokenFee
+	(*TokenFeeTier)(nil),     // 20: proto.TokenFeeTier
+	(*FeeSplit)(nil),         // 21: proto.FeeSplit
+	(*TokenRate)(nil),        // 22: proto.TokenRate
+	(*TokenRateHistory)(nil), // 23: proto.TokenRateHistory
+	(*Token)(nil),            // 24: proto.Token
+	(*HaveRight)(nil),        // 25: proto.HaveRight
+	(*Right)(nil),            // 26: proto.Right
+	(*AccountRights)(nil),    // 27: proto.AccountRights
+	(*Accounts)(nil),         // 28: proto.Accounts
+	(*Operations)(nil),       // 29: proto.Operations
+	(*OperationRights)(nil),  // 30: proto.OperationRights
+	(*Industrial)(nil),       // 31: proto.Industrial
+	(*IndustrialGroup)(nil),  // 32: proto.IndustrialGroup
+	(*AccountInfo)(nil),      // 33: proto.AccountInfo
+	(*Address)(nil),          // 34: proto.Address
+	(*SignedAddress)(nil),    // 35: proto.SignedAddress
+	(*SignaturePolicy)(nil),  // 36: proto.SignaturePolicy
+	(*AclResponse)(nil),      // 37: proto.AclResponse
+	(*Nonce)(nil),            // 38: proto.Nonce
+	(*PendingTx)(nil),        // 39: proto.pendingTx
+	(*Relay)(nil),            // 40: proto.Relay
+	(*SwapLifecycle)(nil),    // 41: proto.SwapLifecycle
+	(*SwapEvent)(nil),        // 42: proto.SwapEvent
+	(*DvpLeg)(nil),           // 43: proto.DvpLeg
+	(*Dvp)(nil),              // 44: proto.Dvp
+	(*Vesting)(nil),          // 45: proto.Vesting
+	(*Hold)(nil),             // 46: proto.Hold
+	(*Freeze)(nil),           // 47: proto.Freeze
+	(*RegulatorEvent)(nil),   // 48: proto.RegulatorEvent
 }
 var file_batch_proto_depIdxs = []int32{
 	1,  // 0: proto.MultiSwap.assets:type_name -> proto.Asset
-	2,  // 1: proto.Batch.swaps:type_name -> proto.Swap
-	3,  // 2: proto.Batch.keys:type_name -> proto.SwapKey
-	3,  // 3: proto.Batch.multi_swaps_keys:type_name -> proto.SwapKey
-	0,  // 4: proto.Batch.multi_swaps:type_name -> proto.MultiSwap
-	7,  // 5: proto.SwapResponse.error:type_name -> proto.ResponseError
-	6,  // 6: proto.SwapResponse.writes:type_name -> proto.WriteElement
-	7,  // 7: proto.TxResponse.error:type_name -> proto.ResponseError
-	6,  // 8: proto.TxResponse.writes:type_name -> proto.WriteElement
-	7,  // 9: proto.BatchTxEvent.error:type_name -> proto.ResponseError
-	10, // 10: proto.BatchTxEvent.events:type_name -> proto.Event
-	9,  // 11: proto.BatchTxEvent.accounting:type_name -> proto.AccountingRecord
-	12, // 12: proto.BatchEvent.events:type_name -> proto.BatchTxEvent
-	11, // 13: proto.BatchResponse.tx_responses:type_name -> proto.TxResponse
-	2,  // 14: proto.BatchResponse.created_swaps:type_name -> proto.Swap
-	8,  // 15: proto.BatchResponse.swap_responses:type_name -> proto.SwapResponse
-	8,  // 16: proto.BatchResponse.swap_key_responses:type_name -> proto.SwapResponse
-	0,  // 17: proto.BatchResponse.created_multi_swap:type_name -> proto.MultiSwap
-	28, // 18: proto.TokenRate.issuer:type_name -> proto.Address
-	16, // 19: proto.Token.fee:type_name -> proto.TokenFee
-	17, // 20: proto.Token.rates:type_name -> proto.TokenRate
-	28, // 21: proto.Right.address:type_name -> proto.Address
-	19, // 22: proto.Right.haveRight:type_name -> proto.HaveRight
-	28, // 23: proto.AccountRights.address:type_name -> proto.Address
-	20, // 24: proto.AccountRights.rights:type_name -> proto.Right
-	28, // 25: proto.Accounts.addresses:type_name -> proto.Address
-	20, // 26: proto.OperationRights.rights:type_name -> proto.Right
-	26, // 27: proto.Industrial.groups:type_name -> proto.IndustrialGroup
-	16, // 28: proto.Industrial.fee:type_name -> proto.TokenFee
-	17, // 29: proto.Industrial.rates:type_name -> proto.TokenRate
-	28, // 30: proto.SignedAddress.address:type_name -> proto.Address
-	30, // 31: proto.SignedAddress.signaturePolicy:type_name -> proto.SignaturePolicy
-	27, // 32: proto.AclResponse.account:type_name -> proto.AccountInfo
-	29, // 33: proto.AclResponse.address:type_name -> proto.SignedAddress
-	28, // 34: proto.pendingTx.sender:type_name -> proto.Address
-	35, // [35:35] is the sub-list for method output_type
-	35, // [35:35] is the sub-list for method input_type
-	35, // [35:35] is the sub-list for extension type_name
-	35, // [35:35] is the sub-list for extension extendee
-	0,  // [0:35] is the sub-list for field type_name
+	3,  // 1: proto.Swap.fee_legs:type_name -> proto.SwapFeeLeg
+	2,  // 2: proto.Batch.swaps:type_name -> proto.Swap
+	4,  // 3: proto.Batch.keys:type_name -> proto.SwapKey
+	4,  // 4: proto.Batch.multi_swaps_keys:type_name -> proto.SwapKey
+	0,  // 5: proto.Batch.multi_swaps:type_name -> proto.MultiSwap
+	8,  // 6: proto.SwapResponse.error:type_name -> proto.ResponseError
+	7,  // 7: proto.SwapResponse.writes:type_name -> proto.WriteElement
+	13, // 8: proto.SwapResponse.events:type_name -> proto.Event
+	8,  // 9: proto.HoldResponse.error:type_name -> proto.ResponseError
+	7,  // 10: proto.HoldResponse.writes:type_name -> proto.WriteElement
+	13, // 11: proto.HoldResponse.events:type_name -> proto.Event
+	11, // 12: proto.TransferRef.accounting:type_name -> proto.AccountingRecord
+	8,  // 13: proto.TxResponse.error:type_name -> proto.ResponseError
+	7,  // 14: proto.TxResponse.writes:type_name -> proto.WriteElement
+	8,  // 15: proto.BatchTxEvent.error:type_name -> proto.ResponseError
+	13, // 16: proto.BatchTxEvent.events:type_name -> proto.Event
+	11, // 17: proto.BatchTxEvent.accounting:type_name -> proto.AccountingRecord
+	15, // 18: proto.BatchEvent.events:type_name -> proto.BatchTxEvent
+	14, // 19: proto.BatchResponse.tx_responses:type_name -> proto.TxResponse
+	2,  // 20: proto.BatchResponse.created_swaps:type_name -> proto.Swap
+	9,  // 21: proto.BatchResponse.swap_responses:type_name -> proto.SwapResponse
+	9,  // 22: proto.BatchResponse.swap_key_responses:type_name -> proto.SwapResponse
+	0,  // 23: proto.BatchResponse.created_multi_swap:type_name -> proto.MultiSwap
+	9,  // 24: proto.BatchResponse.swept_swap_responses:type_name -> proto.SwapResponse
+	10, // 25: proto.BatchResponse.swept_hold_responses:type_name -> proto.HoldResponse
+	20, // 26: proto.TokenFee.tiers:type_name -> proto.TokenFeeTier
+	34, // 27: proto.TokenRate.issuer:type_name -> proto.Address
+	22, // 28: proto.TokenRateHistory.rate:type_name -> proto.TokenRate
+	19, // 29: proto.Token.fee:type_name -> proto.TokenFee
+	22, // 30: proto.Token.rates:type_name -> proto.TokenRate
+	19, // 31: proto.Token.swap_fee:type_name -> proto.TokenFee
+	21, // 32: proto.Token.fee_splits:type_name -> proto.FeeSplit
+	34, // 33: proto.Right.address:type_name -> proto.Address
+	25, // 34: proto.Right.haveRight:type_name -> proto.HaveRight
+	34, // 35: proto.AccountRights.address:type_name -> proto.Address
+	26, // 36: proto.AccountRights.rights:type_name -> proto.Right
+	34, // 37: proto.Accounts.addresses:type_name -> proto.Address
+	26, // 38: proto.OperationRights.rights:type_name -> proto.Right
+	32, // 39: proto.Industrial.groups:type_name -> proto.IndustrialGroup
+	19, // 40: proto.Industrial.fee:type_name -> proto.TokenFee
+	22, // 41: proto.Industrial.rates:type_name -> proto.TokenRate
+	34, // 42: proto.SignedAddress.address:type_name -> proto.Address
+	36, // 43: proto.SignedAddress.signaturePolicy:type_name -> proto.SignaturePolicy
+	33, // 44: proto.AclResponse.account:type_name -> proto.AccountInfo
+	35, // 45: proto.AclResponse.address:type_name -> proto.SignedAddress
+	34, // 46: proto.pendingTx.sender:type_name -> proto.Address
+	40, // 47: proto.pendingTx.relay:type_name -> proto.Relay
+	34, // 48: proto.Relay.relayer:type_name -> proto.Address
+	1,  // 49: proto.SwapEvent.assets:type_name -> proto.Asset
+	43, // 50: proto.Dvp.maker_leg:type_name -> proto.DvpLeg
+	43, // 51: proto.Dvp.taker_leg:type_name -> proto.DvpLeg
+	52, // [52:52] is the sub-list for method output_type
+	52, // [52:52] is the sub-list for method input_type
+	52, // [52:52] is the sub-list for extension type_name
+	52, // [52:52] is the sub-list for extension extendee
+	0,  // [0:52] is the sub-list for field type_name
 }
 
 func init() { file_batch_proto_init() }
@@ -2730,7 +4330,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SwapKey); i {
+			switch v := v.(*SwapFeeLeg); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2742,7 +4342,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Batch); i {
+			switch v := v.(*SwapKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2754,7 +4354,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*InitArgs); i {
+			switch v := v.(*Batch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2766,7 +4366,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*WriteElement); i {
+			switch v := v.(*InitArgs); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2778,7 +4378,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ResponseError); i {
+			switch v := v.(*WriteElement); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2790,7 +4390,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SwapResponse); i {
+			switch v := v.(*ResponseError); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2802,7 +4402,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AccountingRecord); i {
+			switch v := v.(*SwapResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2814,7 +4414,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Event); i {
+			switch v := v.(*HoldResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2826,7 +4426,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TxResponse); i {
+			switch v := v.(*AccountingRecord); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2838,7 +4438,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchTxEvent); i {
+			switch v := v.(*TransferRef); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2850,7 +4450,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchEvent); i {
+			switch v := v.(*Event); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2862,7 +4462,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BatchResponse); i {
+			switch v := v.(*TxResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2874,7 +4474,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Nested); i {
+			switch v := v.(*BatchTxEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2886,7 +4486,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TokenFee); i {
+			switch v := v.(*BatchEvent); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2898,7 +4498,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TokenRate); i {
+			switch v := v.(*BatchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2910,7 +4510,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Token); i {
+			switch v := v.(*Nested); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2922,7 +4522,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HaveRight); i {
+			switch v := v.(*TokenFee); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2934,7 +4534,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Right); i {
+			switch v := v.(*TokenFeeTier); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2946,7 +4546,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AccountRights); i {
+			switch v := v.(*FeeSplit); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2958,7 +4558,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Accounts); i {
+			switch v := v.(*TokenRate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2970,7 +4570,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Operations); i {
+			switch v := v.(*TokenRateHistory); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2982,7 +4582,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OperationRights); i {
+			switch v := v.(*Token); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -2994,7 +4594,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Industrial); i {
+			switch v := v.(*HaveRight); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3006,7 +4606,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*IndustrialGroup); i {
+			switch v := v.(*Right); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3018,7 +4618,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AccountInfo); i {
+			switch v := v.(*AccountRights); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3030,7 +4630,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Address); i {
+			switch v := v.(*Accounts); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3042,7 +4642,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignedAddress); i {
+			switch v := v.(*Operations); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3054,7 +4654,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SignaturePolicy); i {
+			switch v := v.(*OperationRights); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3066,7 +4666,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AclResponse); i {
+			switch v := v.(*Industrial); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3078,7 +4678,7 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Nonce); i {
+			switch v := v.(*IndustrialGroup); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -3090,6 +4690,78 @@ func file_batch_proto_init() {
 			}
 		}
 		file_batch_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AccountInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Address); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignedAddress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignaturePolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AclResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nonce); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*PendingTx); i {
 			case 0:
 				return &v.state
@@ -3101,6 +4773,114 @@ func file_batch_proto_init() {
 				return nil
 			}
 		}
+		file_batch_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Relay); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapLifecycle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[43].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DvpLeg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Dvp); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Vesting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Hold); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Freeze); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_batch_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RegulatorEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -3108,7 +4888,7 @@ func file_batch_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_batch_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   34,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
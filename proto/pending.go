@@ -21,29 +21,40 @@ type pendingTxDump struct {
 	CreatorSKI string       `json:"creator_ski"` //nolint:tagliatelle
 	Timestamp  int64
 	Nonce      uint64
+	Deadline   int64
+	Relayer    *addressDump `json:"relayer,omitempty"`
 }
 
 func (x *PendingTx) DumpJSON() []byte {
-	var sender *addressDump
-	if x.Sender != nil {
-		sender = &addressDump{
-			UserID:       x.Sender.UserID,
-			Address:      base58.CheckEncode(x.Sender.Address[1:], x.Sender.Address[0]),
-			IsIndustrial: x.Sender.IsIndustrial,
-			IsMultisig:   x.Sender.IsMultisig,
-		}
+	var relayer *addressDump
+	if x.Relay != nil {
+		relayer = dumpAddress(x.Relay.Relayer)
 	}
 
 	data, err := json.MarshalIndent(&pendingTxDump{
 		Method:     x.Method,
-		Sender:     sender,
+		Sender:     dumpAddress(x.Sender),
 		Args:       x.Args,
 		CreatorSKI: hex.EncodeToString(x.CreatorSKI),
 		Timestamp:  x.Timestamp,
 		Nonce:      x.Nonce,
+		Deadline:   x.Deadline,
+		Relayer:    relayer,
 	}, "", "  ")
 	if err != nil {
 		panic(err)
 	}
 	return data
 }
+
+func dumpAddress(x *Address) *addressDump {
+	if x == nil {
+		return nil
+	}
+	return &addressDump{
+		UserID:       x.UserID,
+		Address:      base58.CheckEncode(x.Address[1:], x.Address[0]),
+		IsIndustrial: x.IsIndustrial,
+		IsMultisig:   x.IsMultisig,
+	}
+}
@@ -0,0 +1,38 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+)
+
+// TestSignedDeadline - Checking that a transaction signed with a deadline is executed before it
+func TestSignedDeadline(t *testing.T) {
+	ledgerMock, owner := newMulticallTestToken(t)
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+	owner.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "1000")
+
+	deadline := time.Now().Add(time.Minute).Unix()
+	err := user1.RawSignedInvokeWithDeadline(deadline, testTokenCCName, "transfer", user2.Address(), "100", "")
+	assert.NoError(t, err)
+
+	user1.BalanceShouldBe(testTokenCCName, 900)
+	user2.BalanceShouldBe(testTokenCCName, 100)
+}
+
+// TestSignedDeadlineExpired - Checking that a transaction with expired deadline is rejected
+func TestSignedDeadlineExpired(t *testing.T) {
+	ledgerMock, owner := newMulticallTestToken(t)
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+	owner.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "1000")
+
+	deadline := time.Now().Add(-time.Minute).Unix()
+	err := user1.RawSignedInvokeWithDeadline(deadline, testTokenCCName, "transfer", user2.Address(), "100", "")
+	assert.EqualError(t, err, core.ErrDeadlineExpired)
+
+	user1.BalanceShouldBe(testTokenCCName, 1000)
+}
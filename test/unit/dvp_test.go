@@ -0,0 +1,74 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/mock"
+	"github.com/tickets-dao/foundation/v3/proto"
+	"github.com/tickets-dao/foundation/v3/token"
+)
+
+// TestDvpAccept - Checking that both legs of the offer are exchanged in one transaction
+func TestDvpAccept(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+
+	maker := m.NewWallet()
+	taker := m.NewWallet()
+	maker.AddBalance("cc", 1000)
+	taker.AddAllowedBalance("cc", "VT", 500)
+
+	err := maker.RawSignedInvokeWithErrorReturned("cc", "dvpOffer", maker.Address(), "CC", "100", "VT", "50", "60")
+	assert.EqualError(t, err, "maker and taker should be different")
+
+	dvpID := maker.SignedInvoke("cc", "dvpOffer", taker.Address(), "CC", "100", "VT", "50", "60")
+	maker.BalanceShouldBe("cc", 900)
+
+	d := &proto.Dvp{}
+	assert.NoError(t, json.Unmarshal([]byte(maker.Invoke("cc", "dvpGet", dvpID)), d))
+	assert.Equal(t, taker.AddressType().Bytes(), d.Taker)
+
+	err = owner.RawSignedInvokeWithErrorReturned("cc", "dvpAccept", dvpID)
+	assert.EqualError(t, err, "unauthorized")
+
+	taker.SignedInvoke("cc", "dvpAccept", dvpID)
+	maker.BalanceShouldBe("cc", 900)
+	taker.BalanceShouldBe("cc", 100)
+	maker.AllowedBalanceShouldBe("cc", "VT", 50)
+	taker.AllowedBalanceShouldBe("cc", "VT", 450)
+
+	err = taker.RawSignedInvokeWithErrorReturned("cc", "dvpAccept", dvpID)
+	assert.EqualError(t, err, "dvp offer doesn't exist")
+}
+
+// TestDvpCancel - Checking that the maker leg is unlocked when the offer is cancelled
+func TestDvpCancel(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+
+	maker := m.NewWallet()
+	taker := m.NewWallet()
+	maker.AddAllowedBalance("cc", "VT", 500)
+
+	dvpID := maker.SignedInvoke("cc", "dvpOffer", taker.Address(), "VT", "200", "CC", "10", "60")
+	maker.AllowedBalanceShouldBe("cc", "VT", 300)
+
+	err := taker.RawSignedInvokeWithErrorReturned("cc", "dvpCancel", dvpID)
+	assert.EqualError(t, err, "wait for timeout to end")
+
+	maker.SignedInvoke("cc", "dvpCancel", dvpID)
+	maker.AllowedBalanceShouldBe("cc", "VT", 500)
+
+	err = taker.RawSignedInvokeWithErrorReturned("cc", "dvpAccept", dvpID)
+	assert.EqualError(t, err, "dvp offer doesn't exist")
+}
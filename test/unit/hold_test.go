@@ -0,0 +1,188 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/mock"
+	"github.com/tickets-dao/foundation/v3/proto"
+	"github.com/tickets-dao/foundation/v3/token"
+)
+
+// TestHoldCapture - Checking that holds of one owner are captured and released separately
+func TestHoldCapture(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+
+	user := m.NewWallet()
+	merchant := m.NewWallet()
+	shop := m.NewWallet()
+	user.AddBalance("cc", 1000)
+	user.AddAllowedBalance("cc", "VT", 500)
+
+	err := user.RawSignedInvokeWithErrorReturned("cc", "holdCreate", "order-1", "CC", "2000", merchant.Address(), "0", "order")
+	assert.EqualError(t, err, "insufficient funds to process")
+
+	user.SignedInvoke("cc", "holdCreate", "order-1", "CC", "300", merchant.Address(), "0", "order")
+	user.SignedInvoke("cc", "holdCreate", "order-2", "VT", "200", merchant.Address(), "0", "order")
+	err = user.RawSignedInvokeWithErrorReturned("cc", "holdCreate", "order-1", "CC", "100", merchant.Address(), "0", "order")
+	assert.EqualError(t, err, "hold order-1 already exists")
+	user.BalanceShouldBe("cc", 700)
+	user.AllowedBalanceShouldBe("cc", "VT", 300)
+
+	err = shop.RawSignedInvokeWithErrorReturned("cc", "holdCapture", user.Address(), "order-1", shop.Address(), "100")
+	assert.EqualError(t, err, "unauthorized")
+	err = merchant.RawSignedInvokeWithErrorReturned("cc", "holdCapture", user.Address(), "order-1", shop.Address(), "400")
+	assert.EqualError(t, err, "amount exceeds the hold")
+
+	merchant.SignedInvoke("cc", "holdCapture", user.Address(), "order-1", shop.Address(), "100")
+	shop.BalanceShouldBe("cc", 100)
+
+	h := &proto.Hold{}
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("cc", "holdGet", user.Address(), "order-1")), h))
+	assert.Equal(t, []byte{200}, h.Amount)
+
+	err = user.RawSignedInvokeWithErrorReturned("cc", "holdRelease", user.Address(), "order-1")
+	assert.EqualError(t, err, "wait for hold to expire")
+	merchant.SignedInvoke("cc", "holdRelease", user.Address(), "order-1")
+	user.BalanceShouldBe("cc", 900)
+
+	merchant.SignedInvoke("cc", "holdCapture", user.Address(), "order-2", shop.Address(), "200")
+	shop.AllowedBalanceShouldBe("cc", "VT", 200)
+	user.AllowedBalanceShouldBe("cc", "VT", 300)
+
+	var holds []*proto.Hold
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("cc", "holds", user.Address())), &holds))
+	assert.Empty(t, holds)
+}
+
+// TestHoldExpiry - Checking that expired industrial hold can't be captured and is released
+func TestHoldExpiry(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	tt := &TestToken{
+		token.BaseToken{
+			Name:     testTokenWithGroup,
+			Symbol:   testTokenSymbol,
+			Decimals: 8,
+		},
+	}
+	m.NewChainCode(testTokenWithGroup, tt, &core.ContractOptions{}, owner.Address())
+
+	user := m.NewWallet()
+	merchant := m.NewWallet()
+	owner.SignedInvoke(testTokenWithGroup, "industrialBalanceAdd", testTokenWithGroup, user.Address(), "500", "add balance")
+
+	// groups of the token are held by token_group
+	group := testTokenSymbol + "_" + testGroup
+	user.SignedInvoke(testTokenWithGroup, "holdCreate", "order-1", group, "300", merchant.Address(), "1", "order")
+	user.SignedInvoke(testTokenWithGroup, "holdCreate", "order-2", group, "100", merchant.Address(), "1", "order")
+	user.GroupBalanceShouldBe(testTokenWithGroup, testGroup, 100)
+
+	time.Sleep(time.Second + 100*time.Millisecond)
+
+	err := merchant.RawSignedInvokeWithErrorReturned(testTokenWithGroup, "holdCapture", user.Address(), "order-1", merchant.Address(), "100")
+	assert.EqualError(t, err, "hold is expired")
+
+	// anyone can release expired hold, other expired holds of the owner are released with it
+	merchant.SignedInvoke(testTokenWithGroup, "holdRelease", user.Address(), "order-1")
+	user.GroupBalanceShouldBe(testTokenWithGroup, testGroup, 500)
+	err = user.InvokeWithError(testTokenWithGroup, "holdGet", user.Address(), "order-2")
+	assert.EqualError(t, err, core.ErrHoldNotFound)
+
+	user.SignedInvoke(testTokenWithGroup, "holdCreate", "order-3", group, "500", merchant.Address(), "0", "order")
+	user.GroupBalanceShouldBe(testTokenWithGroup, testGroup, 0)
+
+	var holds []*proto.Hold
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke(testTokenWithGroup, "holds", user.Address())), &holds))
+	assert.Len(t, holds, 1)
+	assert.Equal(t, "order-3", holds[0].Id)
+}
+
+// TestHoldSweep - Checking that expired holds are released by the robot
+func TestHoldSweep(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+
+	user := m.NewWallet()
+	merchant := m.NewWallet()
+	user.AddBalance("cc", 1000)
+
+	user.SignedInvoke("cc", "holdCreate", "order-1", "CC", "300", merchant.Address(), "1", "order")
+	user.SignedInvoke("cc", "holdCreate", "order-2", "CC", "200", merchant.Address(), "0", "order")
+	user.BalanceShouldBe("cc", 500)
+
+	_, event := user.SweepExpiredSwaps("cc", 10)
+	assert.Nil(t, event.Error)
+	user.BalanceShouldBe("cc", 500)
+
+	time.Sleep(time.Second + 100*time.Millisecond)
+
+	resp, event := user.SweepExpiredSwaps("cc", 10)
+	assert.Nil(t, event.Error)
+	assert.Len(t, resp.SweptHoldResponses, 1)
+	assert.Equal(t, "order-1", resp.SweptHoldResponses[0].Id)
+	assert.Nil(t, resp.SweptHoldResponses[0].Error)
+	user.BalanceShouldBe("cc", 800)
+
+	var holds []*proto.Hold
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("cc", "holds", user.Address())), &holds))
+	assert.Len(t, holds, 1)
+	assert.Equal(t, "order-2", holds[0].Id)
+}
+
+// TestHoldSweepFailed - Checking that the hold which can't be released is reported and isn't swept again
+func TestHoldSweepFailed(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	tt := &TestToken{
+		token.BaseToken{
+			Name:     testTokenWithGroup,
+			Symbol:   testTokenSymbol,
+			Decimals: 8,
+		},
+	}
+	m.NewChainCode(testTokenWithGroup, tt, &core.ContractOptions{}, owner.Address())
+
+	user := m.NewWallet()
+	merchant := m.NewWallet()
+	owner.SignedInvoke(testTokenWithGroup, "industrialBalanceAdd", testTokenWithGroup, user.Address(), "500", "add balance")
+
+	group := testTokenSymbol + "_" + testGroup
+	user.SignedInvoke(testTokenWithGroup, "holdCreate", "order-1", group, "300", merchant.Address(), "1", "order")
+	user.SignedInvoke(testTokenWithGroup, "holdCreate", "order-2", group, "100", merchant.Address(), "1", "order")
+	// the locked balance of the first hold is gone, so it can't be released
+	owner.SignedInvoke(testTokenWithGroup, "industrialBalanceBurnLocked", testTokenWithGroup+"_"+testGroup, user.Address(), "250", "burn")
+
+	m.AddTime(2 * time.Second)
+
+	resp, event := user.SweepExpiredSwaps(testTokenWithGroup, 1)
+	assert.Nil(t, event.Error)
+	assert.Len(t, resp.SweptHoldResponses, 1)
+	assert.Equal(t, "order-1", resp.SweptHoldResponses[0].Id)
+	assert.NotNil(t, resp.SweptHoldResponses[0].Error)
+	user.GroupBalanceShouldBe(testTokenWithGroup, testGroup, 100)
+
+	// the failed hold doesn't take the limit of the next sweep
+	resp, event = user.SweepExpiredSwaps(testTokenWithGroup, 1)
+	assert.Nil(t, event.Error)
+	assert.Len(t, resp.SweptHoldResponses, 1)
+	assert.Equal(t, "order-2", resp.SweptHoldResponses[0].Id)
+	assert.Nil(t, resp.SweptHoldResponses[0].Error)
+	user.GroupBalanceShouldBe(testTokenWithGroup, testGroup, 200)
+
+	resp, _ = user.SweepExpiredSwaps(testTokenWithGroup, 1)
+	assert.Empty(t, resp.SweptHoldResponses)
+	assert.NoError(t, user.InvokeWithError(testTokenWithGroup, "holdGet", user.Address(), "order-1"))
+}
@@ -0,0 +1,57 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/mock"
+	"github.com/tickets-dao/foundation/v3/token"
+)
+
+// TestJSONLoggerBatchFields - Checking that batched transactions are logged with the batch and tx context
+func TestJSONLoggerBatchFields(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+
+	buf := &bytes.Buffer{}
+	logger, err := core.NewJSONLogger(buf, "info")
+	assert.NoError(t, err)
+
+	tt := &TestToken{
+		token.BaseToken{
+			Name:     testTokenName,
+			Symbol:   testTokenSymbol,
+			Decimals: 8,
+		},
+	}
+	ledgerMock.NewChainCode(testTokenCCName, tt, &core.ContractOptions{Logger: logger}, owner.Address())
+
+	owner.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "1000")
+
+	var txEntry, batchEntry map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &entry))
+		switch {
+		case entry[core.LogFieldTxID] != nil && entry[core.LogFieldMethod] == "emissionAdd":
+			txEntry = entry
+		case entry[core.LogFieldBatchID] != nil && entry[core.LogFieldTxID] == nil:
+			batchEntry = entry
+		}
+	}
+
+	assert.NotNil(t, txEntry)
+	assert.Equal(t, testTokenCCName, txEntry[core.LogFieldChannel])
+	assert.Equal(t, strings.ToUpper(testTokenSymbol), txEntry[core.LogFieldChaincode])
+	assert.Equal(t, owner.Address(), txEntry[core.LogFieldSender])
+	assert.NotEmpty(t, txEntry[core.LogFieldBatchID])
+	assert.Contains(t, txEntry, core.LogFieldElapsedMs)
+
+	assert.NotNil(t, batchEntry)
+	assert.Contains(t, batchEntry, core.LogFieldElapsedMs)
+}
@@ -0,0 +1,112 @@
+package unit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/mock"
+	"github.com/tickets-dao/foundation/v3/token"
+	"golang.org/x/crypto/sha3"
+)
+
+const testMulticallFnName = "multicall"
+
+func newMulticallTestToken(t *testing.T) (*mock.Ledger, *mock.Wallet) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+
+	tt := &TestToken{
+		token.BaseToken{
+			Name:     testTokenName,
+			Symbol:   testTokenSymbol,
+			Decimals: 8,
+		},
+	}
+	ledgerMock.NewChainCode(testTokenCCName, tt, &core.ContractOptions{}, owner.Address())
+	return ledgerMock, owner
+}
+
+func multicallArg(t *testing.T, calls ...core.Call) string {
+	data, err := json.Marshal(calls)
+	assert.NoError(t, err)
+	return string(data)
+}
+
+// TestMulticall - Checking that all calls of a multicall are executed with one signature
+func TestMulticall(t *testing.T) {
+	ledgerMock, owner := newMulticallTestToken(t)
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+	user3 := ledgerMock.NewWallet()
+	owner.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "1000")
+
+	_, res, _ := user1.RawSignedInvoke(testTokenCCName, testMulticallFnName, multicallArg(t,
+		core.Call{Method: "transfer", Args: []string{user2.Address(), "100", ""}},
+		core.Call{Method: "transfer", Args: []string{user3.Address(), "200", ""}},
+	))
+	assert.Equal(t, "", res.Error)
+	assert.Equal(t, "[null,null]", res.Result)
+
+	user1.BalanceShouldBe(testTokenCCName, 700)
+	user2.BalanceShouldBe(testTokenCCName, 100)
+	user3.BalanceShouldBe(testTokenCCName, 200)
+}
+
+// TestMulticallAtomic - Checking that a failed call discards writes of all previous calls
+func TestMulticallAtomic(t *testing.T) {
+	ledgerMock, owner := newMulticallTestToken(t)
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+	owner.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "1000")
+
+	err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, testMulticallFnName, multicallArg(t,
+		core.Call{Method: "transfer", Args: []string{user2.Address(), "100", ""}},
+		core.Call{Method: "transfer", Args: []string{user2.Address(), "5000", ""}},
+	))
+	assert.EqualError(t, err, "call 1 (transfer): insufficient funds to process")
+
+	user1.BalanceShouldBe(testTokenCCName, 1000)
+	user2.BalanceShouldBe(testTokenCCName, 0)
+}
+
+// TestMulticallRejectsQuery - Checking that query methods can't be called in a multicall
+func TestMulticallRejectsQuery(t *testing.T) {
+	ledgerMock, _ := newMulticallTestToken(t)
+	user1 := ledgerMock.NewWallet()
+
+	err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, testMulticallFnName, multicallArg(t,
+		core.Call{Method: "balanceOf", Args: []string{user1.Address()}},
+	))
+	assert.EqualError(t, err, "method balanceOf can't be called in multicall")
+}
+
+// TestMulticallRejectsRelay - Checking that the built-in relay can't be called in a multicall
+func TestMulticallRejectsRelay(t *testing.T) {
+	ledgerMock, _ := newMulticallTestToken(t)
+	user1 := ledgerMock.NewWallet()
+
+	err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, testMulticallFnName, multicallArg(t,
+		core.Call{Method: "relay", Args: []string{"", ""}},
+	))
+	assert.EqualError(t, err, "method relay can't be called in multicall")
+}
+
+// TestMulticallRejectsTwoSwaps - Checking that two calls creating objects by the transaction ID
+// can't be made in one multicall
+func TestMulticallRejectsTwoSwaps(t *testing.T) {
+	ledgerMock, owner := newMulticallTestToken(t)
+	user1 := ledgerMock.NewWallet()
+	owner.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "1000")
+
+	hashed := sha3.Sum256([]byte("123"))
+	swapHash := hex.EncodeToString(hashed[:])
+	err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, testMulticallFnName, multicallArg(t,
+		core.Call{Method: "swapBegin", Args: []string{testTokenSymbol, "VT", "100", swapHash}},
+		core.Call{Method: "swapBegin", Args: []string{testTokenSymbol, "VT", "200", swapHash}},
+	))
+	assert.EqualError(t, err, "methods swapBegin and swapBegin can't be called in the same multicall")
+	user1.BalanceShouldBe(testTokenCCName, 1000)
+}
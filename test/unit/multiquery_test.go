@@ -0,0 +1,44 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+)
+
+const testMultiQueryFnName = "multiQuery"
+
+// TestMultiQuery - Checking that several query methods are answered in one request
+func TestMultiQuery(t *testing.T) {
+	ledgerMock, owner := newMulticallTestToken(t)
+	user1 := ledgerMock.NewWallet()
+	owner.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "1000")
+	user1.AddAllowedBalance(testTokenCCName, "CC", 50)
+
+	resp := user1.Invoke(testTokenCCName, testMultiQueryFnName, multicallArg(t,
+		core.Call{Method: "balanceOf", Args: []string{user1.Address()}},
+		core.Call{Method: "allowedBalanceOf", Args: []string{user1.Address(), "CC"}},
+		core.Call{Method: "getNonce", Args: []string{owner.Address()}},
+		core.Call{Method: "unknown"},
+		core.Call{Method: "transfer", Args: []string{owner.Address(), "1", ""}},
+		core.Call{Method: "balanceOf"},
+	))
+
+	var results []core.QueryResult
+	assert.NoError(t, json.Unmarshal([]byte(resp), &results))
+	assert.Len(t, results, 6)
+
+	assert.Equal(t, "balanceOf", results[0].Method)
+	assert.Equal(t, `"1000"`, string(results[0].Result))
+	assert.Empty(t, results[0].Error)
+
+	assert.Equal(t, `"50"`, string(results[1].Result))
+	assert.NotEqual(t, testMessageEmptyNonce, string(results[2].Result))
+
+	assert.Equal(t, "unknown method unknown", results[3].Error)
+	assert.Equal(t, "method transfer can't be called in multiQuery", results[4].Error)
+	assert.Equal(t, "incorrect number of arguments", results[5].Error)
+	assert.Nil(t, results[5].Result)
+}
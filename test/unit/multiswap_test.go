@@ -426,3 +426,136 @@ func TestAtomicMultiSwapToThirdChannel(t *testing.T) {
 	assert.Error(t, err)
 	assert.Equal(t, "incorrect swap", res.Error)
 }
+
+// TestAtomicMultiSwapBeginTo checks multiswap is credited to the recipient on the other side
+func TestAtomicMultiSwapBeginTo(t *testing.T) {
+	const (
+		tokenBA           = "BA"
+		baCC              = "BA"
+		otfCC             = "OTF"
+		BA1               = "A.101"
+		AllowedBalanceBA1 = tokenBA + "_" + BA1
+	)
+
+	m := mock.NewLedger(t)
+	issuer := m.NewWallet()
+	feeSetter := m.NewWallet()
+	feeAddressSetter := m.NewWallet()
+	owner := m.NewWallet()
+	user1 := m.NewWallet()
+	user2 := m.NewWallet()
+
+	ba := token.BaseToken{
+		Name:     strings.ToLower(baCC),
+		Symbol:   baCC,
+		Decimals: 8,
+	}
+	m.NewChainCode(baCC, &ba, &core.ContractOptions{}, issuer.Address(), feeSetter.Address(), feeAddressSetter.Address())
+	otf := token.BaseToken{
+		Name:     strings.ToLower(otfCC),
+		Symbol:   otfCC,
+		Decimals: 8,
+	}
+	m.NewChainCode(otfCC, &otf, nil, owner.Address())
+
+	user1.AddGivenBalance(baCC, otfCC, 1)
+	user1.AddAllowedBalance(otfCC, AllowedBalanceBA1, 1)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	bytes, err := json.Marshal(types.MultiSwapAssets{
+		Assets: []*types.MultiSwapAsset{
+			{
+				Group:  AllowedBalanceBA1,
+				Amount: "1",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	txID := user1.SignedMultiSwapsInvoke(otfCC, "multiSwapBeginTo", tokenBA, string(bytes), baCC, swapHash, user2.Address())
+	user1.AllowedBalanceShouldBe(otfCC, AllowedBalanceBA1, 0)
+
+	m.WaitMultiSwapAnswer(baCC, txID, time.Second*5)
+	user1.Invoke(baCC, "multiSwapDone", txID, swapKey)
+
+	user1.GroupBalanceShouldBe(baCC, BA1, 0)
+	user2.GroupBalanceShouldBe(baCC, BA1, 1)
+}
+
+// TestAtomicMultiSwapBaseTokenAndGroups checks base token and industrial groups are moved under one hash
+func TestAtomicMultiSwapBaseTokenAndGroups(t *testing.T) {
+	const (
+		tokenBA           = "BA"
+		baCC              = "BA"
+		otfCC             = "OTF"
+		BA1               = "A.101"
+		AllowedBalanceBA1 = tokenBA + "_" + BA1
+	)
+
+	m := mock.NewLedger(t)
+	issuer := m.NewWallet()
+	feeSetter := m.NewWallet()
+	feeAddressSetter := m.NewWallet()
+	owner := m.NewWallet()
+	user1 := m.NewWallet()
+
+	ba := token.BaseToken{
+		Name:     strings.ToLower(baCC),
+		Symbol:   baCC,
+		Decimals: 8,
+	}
+	m.NewChainCode(baCC, &ba, &core.ContractOptions{}, issuer.Address(), feeSetter.Address(), feeAddressSetter.Address())
+	otf := token.BaseToken{
+		Name:     strings.ToLower(otfCC),
+		Symbol:   otfCC,
+		Decimals: 8,
+	}
+	m.NewChainCode(otfCC, &otf, nil, owner.Address())
+
+	user1.AddBalance(baCC, 5)
+	user1.AddTokenBalance(baCC, BA1, 2)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	bytes, err := json.Marshal(types.MultiSwapAssets{
+		Assets: []*types.MultiSwapAsset{
+			{
+				Group:  tokenBA,
+				Amount: "5",
+			},
+			{
+				Group:  AllowedBalanceBA1,
+				Amount: "2",
+			},
+		},
+	})
+	assert.NoError(t, err)
+
+	err = user1.RawSignedInvokeWithErrorReturned(baCC, "multiSwapBegin", "OTF", string(bytes), otfCC, swapHash)
+	assert.EqualError(t, err, "asset BA doesn't belong to token OTF")
+
+	txID := user1.SignedMultiSwapsInvoke(baCC, "multiSwapBegin", tokenBA, string(bytes), otfCC, swapHash)
+	user1.BalanceShouldBe(baCC, 0)
+	user1.GroupBalanceShouldBe(baCC, BA1, 0)
+
+	m.WaitMultiSwapAnswer(otfCC, txID, time.Second*5)
+	user1.Invoke(otfCC, "multiSwapDone", txID, swapKey)
+	user1.AllowedBalanceShouldBe(otfCC, tokenBA, 5)
+	user1.AllowedBalanceShouldBe(otfCC, AllowedBalanceBA1, 2)
+	assert.Nil(t, user1.MultiSwapRobotDone(baCC, txID, swapKey).Error)
+	user1.CheckGivenBalanceShouldBe(baCC, otfCC, 7)
+
+	txID = user1.SignedMultiSwapsInvoke(otfCC, "multiSwapBegin", tokenBA, string(bytes), baCC, swapHash)
+	user1.AllowedBalanceShouldBe(otfCC, tokenBA, 0)
+	user1.AllowedBalanceShouldBe(otfCC, AllowedBalanceBA1, 0)
+
+	m.WaitMultiSwapAnswer(baCC, txID, time.Second*5)
+	user1.Invoke(baCC, "multiSwapDone", txID, swapKey)
+	user1.BalanceShouldBe(baCC, 5)
+	user1.GroupBalanceShouldBe(baCC, BA1, 2)
+	user1.CheckGivenBalanceShouldBe(baCC, otfCC, 0)
+}
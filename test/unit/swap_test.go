@@ -2,10 +2,16 @@ package unit
 
 import (
 	"encoding/hex"
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
 	"github.com/tickets-dao/foundation/v3/mock"
+	"github.com/tickets-dao/foundation/v3/proto"
 	"github.com/tickets-dao/foundation/v3/token"
 	"golang.org/x/crypto/sha3"
 )
@@ -77,3 +83,494 @@ func TestAtomicSwapBack(t *testing.T) {
 	user1.CheckGivenBalanceShouldBe("cc", "CC", 0)
 	user1.CheckGivenBalanceShouldBe("cc", "VT", 550)
 }
+
+// TestSwapLifecycle - Checking that swap stages are kept after the swap is completed
+func TestSwapLifecycle(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+	vt := token.BaseToken{
+		Symbol: "VT",
+	}
+	m.NewChainCode("vt", &vt, &core.ContractOptions{SwapRobotSideTimeout: 60}, owner.Address())
+
+	user1 := m.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	txID := user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "450", swapHash)
+	m.WaitSwapAnswer("vt", txID, time.Second*5)
+	user1.Invoke("vt", "swapDone", txID, swapKey)
+
+	created := &proto.SwapLifecycle{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapLifecycle", txID)), created))
+	assert.Equal(t, core.SwapStatusCreated, created.Status)
+	assert.Equal(t, created.Created+10800, created.Timeout)
+
+	done := &proto.SwapLifecycle{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("vt", "swapLifecycle", txID)), done))
+	assert.Equal(t, core.SwapStatusUserDone, done.Status)
+	assert.Equal(t, done.Answered+60, done.Timeout)
+	assert.NotZero(t, done.UserDone)
+}
+
+// TestSwapBeginWithTimeout - Checking the user side timeout set for a single swap
+func TestSwapBeginWithTimeout(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, &core.ContractOptions{SwapMinUserSideTimeout: 600, SwapMaxUserSideTimeout: 3600}, owner.Address())
+	vt := token.BaseToken{
+		Symbol: "VT",
+	}
+	m.NewChainCode("vt", &vt, nil, owner.Address())
+
+	user1 := m.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	hashed := sha3.Sum256([]byte("123"))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	err := user1.RawSignedInvokeWithErrorReturned("cc", "swapBeginWithTimeout", "CC", "VT", "450", swapHash, "7200")
+	assert.EqualError(t, err, "swap timeout is out of bounds")
+	err = user1.RawSignedInvokeWithErrorReturned("cc", "swapBeginWithTimeout", "CC", "VT", "450", swapHash, "0")
+	assert.EqualError(t, err, "swap timeout is out of bounds")
+	err = user1.RawSignedInvokeWithErrorReturned("vt", "swapBeginWithTimeout", "CC", "VT", "450", swapHash, "600")
+	assert.EqualError(t, err, "custom swap timeout is not allowed")
+
+	txID := user1.SignedInvoke("cc", "swapBeginWithTimeout", "CC", "VT", "450", swapHash, "600")
+	user1.BalanceShouldBe("cc", 550)
+	err = user1.RawSignedInvokeWithErrorReturned("cc", "swapCancel", txID)
+	assert.EqualError(t, err, "wait for timeout to end")
+	m.AddTime(600 * time.Second)
+	user1.SignedInvoke("cc", "swapCancel", txID)
+	user1.BalanceShouldBe("cc", 1000)
+
+	lifecycle := &proto.SwapLifecycle{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapLifecycle", txID)), lifecycle))
+	assert.Equal(t, core.SwapStatusCancelled, lifecycle.Status)
+	assert.Equal(t, lifecycle.Created+600, lifecycle.Timeout)
+	assert.NotZero(t, lifecycle.Cancelled)
+}
+
+// TestQuerySwapsByOwner - Checking the indexes of open swaps by owner and counterpart channel
+func TestQuerySwapsByOwner(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+	vt := token.BaseToken{
+		Symbol: "VT",
+	}
+	m.NewChainCode("vt", &vt, nil, owner.Address())
+
+	user1 := m.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	txIDs := make(map[string]bool)
+	for i := 0; i < 3; i++ {
+		txID := user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "100", swapHash)
+		m.WaitSwapAnswer("vt", txID, time.Second*5)
+		txIDs[txID] = true
+	}
+
+	page := &core.SwapPage{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapsByOwner", user1.Address(), "2", "")), page))
+	assert.Len(t, page.Swaps, 2)
+	assert.NotEmpty(t, page.Bookmark)
+	for _, info := range page.Swaps {
+		assert.True(t, txIDs[hex.EncodeToString(info.Swap.Id)])
+		assert.Greater(t, info.RemainingTimeout, int64(10000))
+	}
+
+	last := &core.SwapPage{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapsByOwner", user1.Address(), "2", page.Bookmark)), last))
+	assert.Len(t, last.Swaps, 1)
+	assert.Empty(t, last.Bookmark)
+
+	answered := &core.SwapPage{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("vt", "swapsByChannel", "CC", "10", "")), answered))
+	assert.Len(t, answered.Swaps, 3)
+
+	doneID := hex.EncodeToString(answered.Swaps[0].Swap.Id)
+	user1.Invoke("vt", "swapDone", doneID, swapKey)
+
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("vt", "swapsByOwner", user1.Address(), "10", "")), answered))
+	assert.Len(t, answered.Swaps, 2)
+	for _, info := range answered.Swaps {
+		assert.NotEqual(t, doneID, hex.EncodeToString(info.Swap.Id))
+	}
+}
+
+// TestSweepExpiredSwaps - Checking that the robot refunds expired swaps in bulk
+func TestSweepExpiredSwaps(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, &core.ContractOptions{SwapMinUserSideTimeout: 600, SwapMaxUserSideTimeout: 3600}, owner.Address())
+	vt := token.BaseToken{
+		Symbol: "VT",
+	}
+	m.NewChainCode("vt", &vt, nil, owner.Address())
+
+	user1 := m.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	hashed := sha3.Sum256([]byte("123"))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	expired1 := user1.SignedInvoke("cc", "swapBeginWithTimeout", "CC", "VT", "300", swapHash, "600")
+	expired2 := user1.SignedInvoke("cc", "swapBeginWithTimeout", "CC", "VT", "300", swapHash, "600")
+	active := user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "100", swapHash)
+	user1.BalanceShouldBe("cc", 300)
+	m.AddTime(600 * time.Second)
+
+	resp, event := owner.SweepExpiredSwaps("cc", 10)
+	assert.Len(t, resp.SweptSwapResponses, 2)
+	for _, r := range resp.SweptSwapResponses {
+		assert.Nil(t, r.Error)
+		assert.Contains(t, []string{expired1, expired2}, hex.EncodeToString(r.Id))
+	}
+	assert.Len(t, event.Accounting, 2)
+	user1.BalanceShouldBe("cc", 900)
+
+	lifecycle := &proto.SwapLifecycle{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapLifecycle", expired1)), lifecycle))
+	assert.Equal(t, core.SwapStatusCancelled, lifecycle.Status)
+	user1.Invoke("cc", "swapGet", active)
+
+	expired3 := user1.SignedInvoke("cc", "swapBeginWithTimeout", "CC", "VT", "200", swapHash, "600")
+	m.AddTime(600 * time.Second)
+	resp, event = owner.DoBatchWithSweep("cc", 10)
+	assert.Len(t, resp.SweptSwapResponses, 1)
+	assert.Equal(t, expired3, hex.EncodeToString(resp.SweptSwapResponses[0].Id))
+	assert.Len(t, event.Accounting, 1)
+	user1.BalanceShouldBe("cc", 900)
+}
+
+// TestSwapEvents - Checking typed events of swap creation, answer and key reveal
+func TestSwapEvents(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+	vt := token.BaseToken{
+		Symbol: "VT",
+	}
+	m.NewChainCode("vt", &vt, nil, owner.Address())
+
+	user1 := m.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	txID, res, _ := user1.RawSignedInvoke("cc", "swapBegin", "CC", "VT", "450", swapHash)
+	assert.Equal(t, "", res.Error)
+	created := &proto.SwapEvent{}
+	assert.NoError(t, pb.Unmarshal(res.Events[core.SwapCreatedEvent], created))
+	assert.Equal(t, txID, hex.EncodeToString(created.Id))
+	assert.Equal(t, "VT", created.To)
+
+	txID = user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "450", swapHash)
+	batchEvents := &proto.BatchEvent{}
+	assert.NoError(t, pb.Unmarshal((<-m.GetStub("vt").ChaincodeEventsChannel).Payload, batchEvents))
+	assert.Len(t, batchEvents.Events, 1)
+	assert.Equal(t, "swapAnswer", batchEvents.Events[0].Method)
+	assert.Equal(t, core.SwapAnsweredEvent, batchEvents.Events[0].Events[0].Name)
+
+	user1.Invoke("vt", "swapDone", txID, swapKey)
+	e := <-m.GetStub("vt").ChaincodeEventsChannel
+	assert.Equal(t, core.SwapKeyRevealedEvent, e.EventName)
+	revealed := &proto.SwapEvent{}
+	assert.NoError(t, pb.Unmarshal(e.Payload, revealed))
+	assert.Equal(t, txID, hex.EncodeToString(revealed.Id))
+	assert.Equal(t, swapKey, revealed.Key)
+}
+
+// TestSwapLegacyKeyEvent - Checking that the legacy key event is set if enabled
+func TestSwapLegacyKeyEvent(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+	vt := token.BaseToken{
+		Symbol: "VT",
+	}
+	m.NewChainCode("vt", &vt, &core.ContractOptions{LegacySwapKeyEvent: true}, owner.Address())
+
+	user1 := m.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	txID := user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "450", swapHash)
+	<-m.GetStub("vt").ChaincodeEventsChannel
+
+	user1.Invoke("vt", "swapDone", txID, swapKey)
+	e := <-m.GetStub("vt").ChaincodeEventsChannel
+	assert.Equal(t, "key", e.EventName)
+	assert.Equal(t, "CC\t"+txID+"\t"+swapKey, string(e.Payload))
+}
+
+// TestSwapBeginTo - Checking that swap is credited to the recipient and refunded to the sender
+func TestSwapBeginTo(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+	vt := token.BaseToken{
+		Symbol: "VT",
+	}
+	m.NewChainCode("vt", &vt, nil, owner.Address())
+
+	user1 := m.NewWallet()
+	user2 := m.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	txID := user1.SignedInvoke("cc", "swapBeginTo", "CC", "VT", "450", swapHash, user2.Address())
+	user1.BalanceShouldBe("cc", 550)
+
+	swap := &proto.Swap{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapGet", txID)), swap))
+	assert.Equal(t, user1.AddressType().Bytes(), swap.Creator)
+	assert.Equal(t, user2.AddressType().Bytes(), swap.Owner)
+
+	page := &core.SwapPage{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapsByOwner", user1.Address(), "10", "")), page))
+	assert.Len(t, page.Swaps, 1)
+
+	m.WaitSwapAnswer("vt", txID, time.Second*5)
+	user1.Invoke("vt", "swapDone", txID, swapKey)
+	user1.AllowedBalanceShouldBe("vt", "CC", 0)
+	user2.AllowedBalanceShouldBe("vt", "CC", 450)
+
+	txID = user1.SignedInvoke("cc", "swapBeginTo", "CC", "VT", "100", swapHash, user2.Address())
+	user1.BalanceShouldBe("cc", 450)
+	m.AddTime(3 * time.Hour)
+	user1.SignedInvoke("cc", "swapCancel", txID)
+	user1.BalanceShouldBe("cc", 550)
+	user2.BalanceShouldBe("cc", 0)
+}
+
+// TestGivenBalanceLimit - Checking that swaps can't send more than the limit set by the issuer
+func TestGivenBalanceLimit(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+	vt := token.BaseToken{
+		Symbol: "VT",
+	}
+	m.NewChainCode("vt", &vt, nil, owner.Address())
+
+	user1 := m.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user1.AddGivenBalance("cc", "VT", 300)
+
+	err := user1.RawSignedInvokeWithErrorReturned("cc", "setGivenBalanceLimit", "VT", "500")
+	assert.EqualError(t, err, "unauthorized")
+	owner.SignedInvoke("cc", "setGivenBalanceLimit", "VT", "500")
+
+	hashed := sha3.Sum256([]byte("123"))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	err = user1.RawSignedInvokeWithErrorReturned("cc", "swapBegin", "CC", "VT", "300", swapHash)
+	assert.EqualError(t, err, "given balance limit to VT is exceeded")
+	canceled := user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "200", swapHash)
+	user1.BalanceShouldBe("cc", 800)
+
+	// the open swap reserves the limit
+	err = user1.RawSignedInvokeWithErrorReturned("cc", "swapBegin", "CC", "VT", "1", swapHash)
+	assert.EqualError(t, err, "given balance limit to VT is exceeded")
+
+	var balances []core.GivenBalance
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "givenBalances")), &balances))
+	assert.Len(t, balances, 1)
+	assert.Equal(t, "VT", balances[0].Contract)
+	assert.Equal(t, "300", balances[0].Balance.String())
+	assert.Equal(t, "200", balances[0].Reserved.String())
+	assert.Equal(t, "500", balances[0].Limit.String())
+
+	// the refund releases the reservation and the robot done turns it into the given balance
+	m.AddTime(3 * time.Hour)
+	user1.SignedInvoke("cc", "swapCancel", canceled)
+	txID := user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "200", swapHash)
+	m.WaitSwapAnswer("vt", txID, time.Second*5)
+	user1.Invoke("vt", "swapDone", txID, "123")
+	owner.SwapRobotDone("cc", txID, "123")
+
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "givenBalances")), &balances))
+	assert.Len(t, balances, 1)
+	assert.Equal(t, "500", balances[0].Balance.String())
+	assert.Equal(t, "0", balances[0].Reserved.String())
+}
+
+// TestGivenBalanceLimitAnswer - Checking that the value returned by the answered swap
+// stays reserved till the swap is done
+func TestGivenBalanceLimitAnswer(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	cc := token.BaseToken{
+		Symbol: "CC",
+	}
+	m.NewChainCode("cc", &cc, nil, owner.Address())
+	vt := token.BaseToken{
+		Symbol: "VT",
+	}
+	m.NewChainCode("vt", &vt, nil, owner.Address())
+
+	user1 := m.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user1.AddGivenBalance("cc", "VT", 500)
+	user1.AddAllowedBalance("vt", "CC", 500)
+	owner.SignedInvoke("cc", "setGivenBalanceLimit", "VT", "500")
+
+	hashed := sha3.Sum256([]byte("123"))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	back := user1.SignedInvoke("vt", "swapBegin", "CC", "CC", "200", swapHash)
+	m.WaitSwapAnswer("cc", back, time.Second*5)
+
+	// the answer can still be refunded to VT, so the returned value doesn't free the limit
+	_, res, _ := user1.RawSignedInvoke("cc", "swapBegin", "CC", "VT", "200", swapHash)
+	assert.Equal(t, "given balance limit to VT is exceeded", res.Error)
+
+	var balances []core.GivenBalance
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "givenBalances")), &balances))
+	assert.Equal(t, "300", balances[0].Balance.String())
+	assert.Equal(t, "200", balances[0].Reserved.String())
+
+	user1.Invoke("cc", "swapDone", back, "123")
+	user1.BalanceShouldBe("cc", 1200)
+	user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "200", swapHash)
+}
+
+// TestSwapRoute - Checking that one key settles every hop of the swap through the token contract
+func TestSwapRoute(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	for _, symbol := range []string{"CC", "VT", "XT"} {
+		m.NewChainCode(strings.ToLower(symbol), &token.BaseToken{Symbol: symbol}, nil, owner.Address())
+	}
+
+	user1 := m.NewWallet()
+	user1.AddAllowedBalance("vt", "CC", 1000)
+	user1.AddGivenBalance("cc", "VT", 1000)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	err := user1.RawSignedInvokeWithErrorReturned("vt", "swapBeginRoute", "CC", "XT,CC", "400", swapHash)
+	assert.EqualError(t, err, core.ErrIncorrectSwap)
+
+	txID := user1.SignedInvoke("vt", "swapBeginRoute", "CC", "CC,XT", "400", swapHash)
+	user1.AllowedBalanceShouldBe("vt", "CC", 600)
+	user1.CheckGivenBalanceShouldBe("cc", "VT", 600)
+
+	forwarded := &proto.Swap{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapGet", txID)), forwarded))
+	assert.Equal(t, "VT", forwarded.ForwardedFrom)
+	assert.Equal(t, "XT", forwarded.To)
+	err = user1.InvokeWithError("cc", "swapDone", txID, swapKey)
+	assert.EqualError(t, err, core.ErrIncorrectSwap)
+
+	user1.Invoke("xt", "swapDone", txID, swapKey)
+	user1.AllowedBalanceShouldBe("xt", "CC", 400)
+
+	assert.Nil(t, user1.SwapRobotDone("cc", txID, swapKey).Error)
+	user1.CheckGivenBalanceShouldBe("cc", "XT", 400)
+	assert.Nil(t, user1.SwapRobotDone("vt", txID, swapKey).Error)
+	user1.CheckGivenBalanceShouldBe("cc", "VT", 600)
+}
+
+// TestSwapTimeoutOptions - Checking that the user side timeout can't end before the robot side of the swap
+func TestSwapTimeoutOptions(t *testing.T) {
+	_, err := core.NewChainCode(&token.BaseToken{Symbol: "CC"}, "atomyzeMSP", &core.ContractOptions{SwapUserSideTimeout: 300})
+	assert.EqualError(t, err, "swap user side timeout should be greater than 360")
+	_, err = core.NewChainCode(&token.BaseToken{Symbol: "CC"}, "atomyzeMSP", &core.ContractOptions{SwapMaxUserSideTimeout: 3600})
+	assert.EqualError(t, err, "swap min user side timeout should be greater than 360")
+	_, err = core.NewChainCode(&token.BaseToken{Symbol: "CC"}, "atomyzeMSP", &core.ContractOptions{
+		SwapRobotSideTimeout:   60,
+		SwapMinUserSideTimeout: 600,
+		SwapMaxUserSideTimeout: 300,
+	})
+	assert.EqualError(t, err, "swap max user side timeout is less than min")
+	_, err = core.NewChainCode(&token.BaseToken{Symbol: "CC"}, "atomyzeMSP", &core.ContractOptions{
+		SwapRobotSideTimeout:   60,
+		SwapMinUserSideTimeout: 180,
+		SwapMaxUserSideTimeout: 3600,
+	})
+	assert.NoError(t, err)
+}
+
+// TestSwapRouteTimeouts - Checking that timeouts decrease along the route, so the middle hop
+// can't be refunded by the sweep after the key is revealed on the last hop
+func TestSwapRouteTimeouts(t *testing.T) {
+	m := mock.NewLedger(t)
+	owner := m.NewWallet()
+	m.NewChainCode("vt", &token.BaseToken{Symbol: "VT"}, &core.ContractOptions{SwapUserSideTimeout: 400}, owner.Address())
+	m.NewChainCode("cc", &token.BaseToken{Symbol: "CC"}, nil, owner.Address())
+	m.NewChainCode("xt", &token.BaseToken{Symbol: "XT"}, nil, owner.Address())
+
+	user1 := m.NewWallet()
+	user1.AddAllowedBalance("vt", "CC", 1000)
+	user1.AddGivenBalance("cc", "VT", 1000)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	txID := user1.SignedInvoke("vt", "swapBeginRoute", "CC", "CC,XT", "400", swapHash)
+	origin, forwarded, last := &proto.Swap{}, &proto.Swap{}, &proto.Swap{}
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("vt", "swapGet", txID)), origin))
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapGet", txID)), forwarded))
+	assert.NoError(t, json.Unmarshal([]byte(user1.Invoke("xt", "swapGet", txID)), last))
+	assert.GreaterOrEqual(t, origin.Timeout-forwarded.Timeout, int64(60))
+	assert.GreaterOrEqual(t, forwarded.Timeout-last.Timeout, int64(60))
+
+	// the key is revealed right before the last hop expires
+	m.AddTime(time.Duration(last.Timeout-time.Now().Unix()-2) * time.Second)
+	user1.Invoke("xt", "swapDone", txID, swapKey)
+	user1.AllowedBalanceShouldBe("xt", "CC", 400)
+
+	// the middle hop is still alive when the last one expires, so the robot finishes it
+	m.AddTime(time.Duration(forwarded.Timeout-last.Timeout-5) * time.Second)
+	resp, _ := owner.SweepExpiredSwaps("cc", 10)
+	assert.Len(t, resp.SweptSwapResponses, 0)
+	assert.Nil(t, user1.SwapRobotDone("cc", txID, swapKey).Error)
+	user1.CheckGivenBalanceShouldBe("cc", "XT", 400)
+	user1.CheckGivenBalanceShouldBe("cc", "VT", 600)
+}
@@ -0,0 +1,188 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/mock"
+	"github.com/tickets-dao/foundation/v3/token"
+)
+
+type RestrictedToken struct {
+	token.BaseToken
+	LockUpUntil  int64
+	Jurisdiction string
+}
+
+func (rt *RestrictedToken) TransferRules() []core.TransferRule {
+	return []core.TransferRule{
+		&core.LockUpRule{Until: rt.LockUpUntil, Exempt: []*types.Address{rt.Issuer()}},
+		&core.MaxBalanceRule{Max: big.NewInt(1000)},
+		&core.MaxHoldersRule{Max: 3},
+		&core.JurisdictionRule{
+			Allowed: []string{"EU"},
+			Jurisdiction: func(kycHash string) (string, error) {
+				if kycHash == "" {
+					return "", errors.New("kyc hash is empty")
+				}
+				return rt.Jurisdiction, nil
+			},
+		},
+	}
+}
+
+func canTransferCode(t *testing.T, w *mock.Wallet, from *mock.Wallet, to *mock.Wallet, amount string) string {
+	result := &core.CanTransfer{}
+	assert.NoError(t, json.Unmarshal([]byte(w.Invoke("rt", "canTransfer", from.Address(), to.Address(), amount)), result))
+	return result.Code
+}
+
+// TestTransferRules - Checking that transfers are restricted by rules of the token with reason codes
+func TestTransferRules(t *testing.T) {
+	m := mock.NewLedger(t)
+	issuer := m.NewWallet()
+	rt := &RestrictedToken{
+		BaseToken:    token.BaseToken{Symbol: "RT"},
+		LockUpUntil:  time.Now().Add(time.Hour).Unix(),
+		Jurisdiction: "EU",
+	}
+	m.NewChainCode("rt", rt, nil, issuer.Address())
+
+	first := m.NewWallet()
+	second := m.NewWallet()
+	third := m.NewWallet()
+	issuer.AddBalance("rt", 5000)
+
+	// the issuer is exempt from the lock up
+	issuer.SignedInvoke("rt", "transfer", first.Address(), "500", "")
+	issuer.SignedInvoke("rt", "transfer", second.Address(), "500", "")
+
+	assert.Equal(t, core.TransferCodeLockUp, canTransferCode(t, first, first, second, "100"))
+	err := first.RawSignedInvokeWithErrorReturned("rt", "transfer", second.Address(), "100", "")
+	assert.EqualError(t, err, fmt.Sprintf("token is locked up until %d", rt.LockUpUntil))
+
+	assert.Equal(t, core.TransferCodeMaxBalance, canTransferCode(t, issuer, issuer, first, "501"))
+	assert.Equal(t, core.TransferCodeMaxHolders, canTransferCode(t, issuer, issuer, third, "1"))
+	assert.Equal(t, core.TransferCodeInsufficientBalance, canTransferCode(t, first, first, third, "501"))
+	assert.Equal(t, core.TransferCodeOK, canTransferCode(t, issuer, issuer, first, "500"))
+
+	err = issuer.RawSignedInvokeWithErrorReturned("rt", "transfer", third.Address(), "1", "")
+	assert.EqualError(t, err, "number of holders can't exceed 3")
+
+	issuer.SignedInvoke("rt", "freeze", first.Address(), "true", "AML")
+	assert.Equal(t, core.TransferCodeFrozen, canTransferCode(t, issuer, issuer, first, "1"))
+}
+
+// TestTransferJurisdictionRule - Checking that transfers to not allowed jurisdictions are restricted
+func TestTransferJurisdictionRule(t *testing.T) {
+	m := mock.NewLedger(t)
+	issuer := m.NewWallet()
+	rt := &RestrictedToken{
+		BaseToken:    token.BaseToken{Symbol: "RT"},
+		Jurisdiction: "US",
+	}
+	m.NewChainCode("rt", rt, nil, issuer.Address())
+
+	user := m.NewWallet()
+	issuer.AddBalance("rt", 5000)
+
+	assert.Equal(t, core.TransferCodeJurisdiction, canTransferCode(t, issuer, issuer, user, "1"))
+	err := issuer.RawSignedInvokeWithErrorReturned("rt", "transfer", user.Address(), "1", "")
+	assert.EqualError(t, err, "jurisdiction US of "+user.Address()+" isn't allowed")
+}
+
+// TestTransferRulesBatch - Checking that holders added earlier in the batch are counted by the rule
+func TestTransferRulesBatch(t *testing.T) {
+	m := mock.NewLedger(t)
+	issuer := m.NewWallet()
+	rt := &RestrictedToken{
+		BaseToken:    token.BaseToken{Symbol: "RT"},
+		Jurisdiction: "EU",
+	}
+	m.NewChainCode("rt", rt, nil, issuer.Address())
+
+	first := m.NewWallet()
+	second := m.NewWallet()
+	third := m.NewWallet()
+	issuer.AddBalance("rt", 5000)
+	issuer.SignedInvoke("rt", "transfer", first.Address(), "500", "")
+
+	data, err := json.Marshal([]core.Call{
+		{Method: "transfer", Args: []string{second.Address(), "100", ""}},
+		{Method: "transfer", Args: []string{third.Address(), "100", ""}},
+	})
+	assert.NoError(t, err)
+	err = issuer.RawSignedInvokeWithErrorReturned("rt", "multicall", string(data))
+	assert.EqualError(t, err, "call 1 (transfer): number of holders can't exceed 3")
+
+	second.BalanceShouldBe("rt", 0)
+	third.BalanceShouldBe("rt", 0)
+}
+
+// TestTransferRulesCredit - Checking that rules are applied to captured holds and emission
+func TestTransferRulesCredit(t *testing.T) {
+	m := mock.NewLedger(t)
+	issuer := m.NewWallet()
+	rt := &RestrictedToken{
+		BaseToken:    token.BaseToken{Symbol: "RT"},
+		LockUpUntil:  time.Now().Add(time.Hour).Unix(),
+		Jurisdiction: "EU",
+	}
+	m.NewChainCode("rt", rt, nil, issuer.Address())
+
+	user := m.NewWallet()
+	merchant := m.NewWallet()
+	issuer.AddBalance("rt", 5000)
+	issuer.SignedInvoke("rt", "transfer", user.Address(), "500", "")
+
+	issuer.SignedInvoke("rt", "holdCreate", "order-1", "RT", "600", merchant.Address(), "0", "order")
+	err := merchant.RawSignedInvokeWithErrorReturned("rt", "holdCapture", issuer.Address(), "order-1", user.Address(), "600")
+	assert.EqualError(t, err, fmt.Sprintf("balance of %s can't exceed 1000", user.Address()))
+	merchant.SignedInvoke("rt", "holdCapture", issuer.Address(), "order-1", user.Address(), "500")
+	user.BalanceShouldBe("rt", 1000)
+
+	err = issuer.RawSignedInvokeWithErrorReturned("rt", "mint", user.Address(), "1")
+	assert.EqualError(t, err, fmt.Sprintf("balance of %s can't exceed 1000", user.Address()))
+
+	// emission has no sender, so it isn't locked up
+	issuer.SignedInvoke("rt", "mint", merchant.Address(), "100")
+	merchant.BalanceShouldBe("rt", 100)
+}
+
+// TestTransferRulesLocked - Checking that locked balances are counted by holders and balance rules
+func TestTransferRulesLocked(t *testing.T) {
+	m := mock.NewLedger(t)
+	issuer := m.NewWallet()
+	rt := &RestrictedToken{
+		BaseToken:    token.BaseToken{Symbol: "RT"},
+		Jurisdiction: "EU",
+	}
+	m.NewChainCode("rt", rt, nil, issuer.Address())
+
+	first := m.NewWallet()
+	second := m.NewWallet()
+	third := m.NewWallet()
+	issuer.AddBalance("rt", 5000)
+	issuer.SignedInvoke("rt", "transfer", first.Address(), "500", "")
+	issuer.SignedInvoke("rt", "transfer", second.Address(), "500", "")
+
+	// the whole balance on hold still counts for the holder and for the max balance
+	first.SignedInvoke("rt", "holdCreate", "order", "RT", "500", second.Address(), "0", "order")
+	first.BalanceShouldBe("rt", 0)
+	assert.Equal(t, core.TransferCodeMaxBalance, canTransferCode(t, issuer, issuer, first, "501"))
+	assert.Equal(t, core.TransferCodeMaxHolders, canTransferCode(t, issuer, issuer, third, "1"))
+	err := issuer.RawSignedInvokeWithErrorReturned("rt", "transfer", first.Address(), "501", "")
+	assert.EqualError(t, err, "balance of "+first.Address()+" can't exceed 1000")
+
+	// the holder is gone when the hold is captured
+	second.SignedInvoke("rt", "holdCapture", first.Address(), "order", second.Address(), "500")
+	assert.Equal(t, core.TransferCodeOK, canTransferCode(t, issuer, issuer, third, "1"))
+	issuer.SignedInvoke("rt", "transfer", third.Address(), "1", "")
+}
@@ -0,0 +1,80 @@
+package token
+
+import (
+	"errors"
+
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+)
+
+// QueryAllowance returns the amount which the spender can transfer from the owner
+func (bt *BaseToken) QueryAllowance(owner *types.Address, spender *types.Address) (*big.Int, error) {
+	return bt.AllowanceGet(owner, spender)
+}
+
+// TxApprove allows the spender to transfer the amount from the sender, the previous allowance is replaced
+func (bt *BaseToken) TxApprove(sender *types.Sender, spender *types.Address, amount *big.Int) error {
+	if sender.Equal(spender) {
+		return errors.New("impossible operation")
+	}
+	return bt.AllowanceSet(sender.Address(), spender, amount)
+}
+
+// TxIncreaseAllowance increases the amount which the spender can transfer from the sender
+func (bt *BaseToken) TxIncreaseAllowance(sender *types.Sender, spender *types.Address, amount *big.Int) error {
+	if sender.Equal(spender) {
+		return errors.New("impossible operation")
+	}
+	return bt.AllowanceAdd(sender.Address(), spender, amount)
+}
+
+// TxDecreaseAllowance decreases the amount which the spender can transfer from the sender
+func (bt *BaseToken) TxDecreaseAllowance(sender *types.Sender, spender *types.Address, amount *big.Int) error {
+	return bt.AllowanceSub(sender.Address(), spender, amount)
+}
+
+// TxTransferFrom transfers the amount from the owner within the allowance given to the sender.
+// The fee is paid by the sender like the fee of the transfer, it's free only if the owner,
+// the recipient and the sender are addresses of the same user.
+func (bt *BaseToken) TxTransferFrom(sender *types.Sender, from *types.Address, to *types.Address, amount *big.Int) error {
+	if from.Equal(to) {
+		return errors.New("impossible operation")
+	}
+
+	if amount.Cmp(big.NewInt(0)) == 0 {
+		return errors.New("amount should be more than zero")
+	}
+
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return err
+	}
+
+	if bt.config.Fee != nil && len(bt.config.FeeAddress) == 0 {
+		return errors.New("fee address is not set")
+	}
+
+	if err := bt.AllowanceSub(from, sender.Address(), amount); err != nil {
+		return err
+	}
+
+	if err := bt.TokenBalanceTransfer(from, to, amount, "transferFrom"); err != nil {
+		return err
+	}
+
+	payer, fee, err := bt.feePayer(sender, amount)
+	if err != nil {
+		return err
+	}
+
+	if !transferFromSameUser(payer, from, to) {
+		return bt.payFee(payer, fee, "transferFrom fee")
+	}
+
+	return nil
+}
+
+// transferFromSameUser reports whether the transfer doesn't leave the user of the fee payer,
+// only such transfers are free like transfers between addresses of the same user
+func transferFromSameUser(payer *types.Address, from *types.Address, to *types.Address) bool {
+	return payer.IsUserIDSame(from) && payer.IsUserIDSame(to)
+}
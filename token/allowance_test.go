@@ -0,0 +1,74 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	ma "github.com/tickets-dao/foundation/v3/mock"
+)
+
+func TestAllowance(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	feeAddressSetter := mock.NewWallet()
+	feeSetter := mock.NewWallet()
+	feeAggregator := mock.NewWallet()
+	owner := mock.NewWallet()
+	spender := mock.NewWallet()
+	receiver := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address(), feeSetter.Address(), feeAddressSetter.Address())
+
+	owner.AddBalance("vt", 1000)
+	spender.AddBalance("vt", 100)
+
+	err := owner.RawSignedInvokeWithErrorReturned("vt", "approve", owner.Address(), "100")
+	assert.EqualError(t, err, "impossible operation")
+
+	owner.SignedInvoke("vt", "approve", spender.Address(), "300")
+	assert.Equal(t, "\"300\"", spender.Invoke("vt", "allowance", owner.Address(), spender.Address()))
+	owner.SignedInvoke("vt", "increaseAllowance", spender.Address(), "100")
+	owner.SignedInvoke("vt", "decreaseAllowance", spender.Address(), "50")
+	assert.Equal(t, "\"350\"", spender.Invoke("vt", "allowance", owner.Address(), spender.Address()))
+
+	err = owner.RawSignedInvokeWithErrorReturned("vt", "decreaseAllowance", spender.Address(), "500")
+	assert.EqualError(t, err, "insufficient allowance")
+
+	err = spender.RawSignedInvokeWithErrorReturned("vt", "transferFrom", owner.Address(), receiver.Address(), "400")
+	assert.EqualError(t, err, "insufficient allowance")
+
+	feeAddressSetter.SignedInvoke("vt", "setFeeAddress", feeAggregator.Address())
+	feeSetter.SignedInvoke("vt", "setFee", "VT", "500000", "1", "0")
+
+	spender.SignedInvoke("vt", "transferFrom", owner.Address(), receiver.Address(), "200")
+	owner.BalanceShouldBe("vt", 800)
+	receiver.BalanceShouldBe("vt", 200)
+	spender.BalanceShouldBe("vt", 99)
+	feeAggregator.BalanceShouldBe("vt", 1)
+	assert.Equal(t, "\"150\"", spender.Invoke("vt", "allowance", owner.Address(), spender.Address()))
+
+	owner.SignedInvoke("vt", "approve", spender.Address(), "0")
+	assert.Equal(t, "\"0\"", spender.Invoke("vt", "allowance", owner.Address(), spender.Address()))
+}
+
+func TestTransferFromSameUser(t *testing.T) {
+	spender := &types.Address{UserID: "spender"}
+	owner := &types.Address{UserID: "owner"}
+	ownerOther := &types.Address{UserID: "owner"}
+	spenderOther := &types.Address{UserID: "spender"}
+
+	// the owner moves funds between own addresses, but the fee is paid by the spender
+	assert.False(t, transferFromSameUser(spender, owner, ownerOther))
+	// the spender moves the owner's funds to itself
+	assert.False(t, transferFromSameUser(spender, owner, spenderOther))
+	assert.True(t, transferFromSameUser(owner, ownerOther, owner))
+}
@@ -8,17 +8,23 @@ import (
 )
 
 func (bt *BaseToken) CheckLimitsAndPrice(method string, amount *big.Int, currency string) (*big.Int, error) {
+	price, _, err := bt.checkLimitsAndPrice(method, amount, currency)
+	return price, err
+}
+
+// checkLimitsAndPrice returns the price and the version of the rate used for it
+func (bt *BaseToken) checkLimitsAndPrice(method string, amount *big.Int, currency string) (*big.Int, uint64, error) {
 	rate, exists, err := bt.GetRateAndLimits(method, currency)
 	if err != nil {
-		return big.NewInt(0), err
+		return big.NewInt(0), 0, err
 	}
 	if !exists {
-		return big.NewInt(0), errors.New("impossible to buy for this currency")
+		return big.NewInt(0), 0, errors.New("impossible to buy for this currency")
 	}
 	if !rate.InLimit(amount) {
-		return big.NewInt(0), errors.New("amount out of limits")
+		return big.NewInt(0), 0, errors.New("amount out of limits")
 	}
-	return rate.CalcPrice(amount, RateDecimal), nil
+	return rate.CalcPrice(amount, RateDecimal), rate.Version, nil
 }
 
 func (bt *BaseToken) TxBuyToken(sender *types.Sender, amount *big.Int, currency string) error {
@@ -30,19 +36,21 @@ func (bt *BaseToken) TxBuyToken(sender *types.Sender, amount *big.Int, currency
 		return errors.New("amount should be more than zero")
 	}
 
-	price, err := bt.CheckLimitsAndPrice("buyToken", amount, currency)
-	if err != nil {
+	// versions of rates set before the history are given before the deal
+	if err := bt.ratesLoad(); err != nil {
 		return err
 	}
-
-	if err = bt.AllowedBalanceTransfer(currency, sender.Address(), bt.Issuer(), price, "buyToken"); err != nil {
+	price, version, err := bt.checkLimitsAndPrice("buyToken", amount, currency)
+	if err != nil {
 		return err
 	}
 
-	if err = bt.TokenBalanceTransfer(bt.Issuer(), sender.Address(), amount, "buyToken"); err != nil {
-		return err
-	}
-	return nil
+	return bt.WithRateVersion(version, func() error {
+		if err := bt.AllowedBalanceTransfer(currency, sender.Address(), bt.Issuer(), price, "buyToken"); err != nil {
+			return err
+		}
+		return bt.TokenBalanceTransfer(bt.Issuer(), sender.Address(), amount, "buyToken")
+	})
 }
 
 func (bt *BaseToken) TxBuyBack(sender *types.Sender, amount *big.Int, currency string) error {
@@ -54,17 +62,19 @@ func (bt *BaseToken) TxBuyBack(sender *types.Sender, amount *big.Int, currency s
 		return errors.New("amount should be more than zero")
 	}
 
-	price, err := bt.CheckLimitsAndPrice("buyBack", amount, currency)
-	if err != nil {
+	// versions of rates set before the history are given before the deal
+	if err := bt.ratesLoad(); err != nil {
 		return err
 	}
-
-	if err = bt.AllowedBalanceTransfer(currency, bt.Issuer(), sender.Address(), price, "buyBack"); err != nil {
+	price, version, err := bt.checkLimitsAndPrice("buyBack", amount, currency)
+	if err != nil {
 		return err
 	}
 
-	if err = bt.TokenBalanceTransfer(sender.Address(), bt.Issuer(), amount, "buyBack"); err != nil {
-		return err
-	}
-	return nil
+	return bt.WithRateVersion(version, func() error {
+		if err := bt.AllowedBalanceTransfer(currency, bt.Issuer(), sender.Address(), price, "buyBack"); err != nil {
+			return err
+		}
+		return bt.TokenBalanceTransfer(sender.Address(), bt.Issuer(), amount, "buyBack")
+	})
 }
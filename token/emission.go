@@ -0,0 +1,69 @@
+package token
+
+import (
+	"errors"
+
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+)
+
+// TxMint issues the amount of tokens to the address
+func (bt *BaseToken) TxMint(sender *types.Sender, address *types.Address, amount *big.Int) error {
+	if !sender.Equal(bt.Issuer()) {
+		return errors.New("unauthorized")
+	}
+	if amount.Sign() <= 0 {
+		return errors.New("amount should be positive")
+	}
+	if err := bt.TokenBalanceAdd(address, amount, "mint"); err != nil {
+		return err
+	}
+	return bt.EmissionAdd(amount)
+}
+
+// TxBurn withdraws the amount of tokens from the address
+func (bt *BaseToken) TxBurn(sender *types.Sender, address *types.Address, amount *big.Int) error {
+	if !sender.Equal(bt.Issuer()) {
+		return errors.New("unauthorized")
+	}
+	if amount.Sign() <= 0 {
+		return errors.New("amount should be positive")
+	}
+	if err := bt.TokenBalanceSub(address, amount, "burn"); err != nil {
+		return err
+	}
+	return bt.EmissionSub(amount)
+}
+
+// TxRedeem withdraws the amount of tokens from the balance of the holder
+func (bt *BaseToken) TxRedeem(sender *types.Sender, amount *big.Int) error {
+	if amount.Sign() <= 0 {
+		return errors.New("amount should be positive")
+	}
+	if err := bt.TokenBalanceSub(sender.Address(), amount, "redeem"); err != nil {
+		return err
+	}
+	return bt.EmissionSub(amount)
+}
+
+// TxSetMaxSupply limits the total emission of the token. Once set the limit is a guarantee to holders,
+// so it can only be lowered down to the total emission and can't be removed.
+func (bt *BaseToken) TxSetMaxSupply(sender *types.Sender, maxSupply *big.Int) error {
+	if !sender.Equal(bt.Issuer()) {
+		return errors.New("unauthorized")
+	}
+	if maxSupply.Sign() <= 0 {
+		return errors.New("max supply should be positive")
+	}
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return err
+	}
+	if len(bt.config.MaxSupply) > 0 && maxSupply.Cmp(new(big.Int).SetBytes(bt.config.MaxSupply)) > 0 {
+		return errors.New("max supply can only be lowered")
+	}
+	if maxSupply.Cmp(new(big.Int).SetBytes(bt.config.TotalEmission)) < 0 {
+		return errors.New("max supply is less than total emission")
+	}
+	bt.config.MaxSupply = maxSupply.Bytes()
+	return bt.saveConfig()
+}
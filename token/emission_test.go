@@ -0,0 +1,61 @@
+package token
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	ma "github.com/tickets-dao/foundation/v3/mock"
+)
+
+func TestMintBurnRedeem(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	user := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address())
+
+	err := user.RawSignedInvokeWithErrorReturned("vt", "mint", user.Address(), "100")
+	assert.EqualError(t, err, "unauthorized")
+
+	issuer.SignedInvoke("vt", "setMaxSupply", "1000")
+	issuer.SignedInvoke("vt", "mint", user.Address(), "800")
+	user.BalanceShouldBe("vt", 800)
+
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "mint", user.Address(), "201")
+	assert.EqualError(t, err, "max supply is exceeded")
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "setMaxSupply", "700")
+	assert.EqualError(t, err, "max supply is less than total emission")
+
+	issuer.SignedInvoke("vt", "burn", user.Address(), "300")
+	user.SignedInvoke("vt", "redeem", "100")
+	user.BalanceShouldBe("vt", 400)
+
+	err = user.RawSignedInvokeWithErrorReturned("vt", "redeem", "500")
+	assert.EqualError(t, err, "insufficient funds to process")
+
+	metadata := &Metadata{}
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "metadata")), metadata))
+	assert.Equal(t, big.NewInt(400), metadata.TotalEmission)
+	assert.Equal(t, big.NewInt(1000), metadata.MaxSupply)
+
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "setMaxSupply", "0")
+	assert.EqualError(t, err, "max supply should be positive")
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "setMaxSupply", "1001")
+	assert.EqualError(t, err, "max supply can only be lowered")
+
+	issuer.SignedInvoke("vt", "setMaxSupply", "500")
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "mint", user.Address(), "101")
+	assert.EqualError(t, err, "max supply is exceeded")
+	issuer.SignedInvoke("vt", "mint", user.Address(), "100")
+	user.BalanceShouldBe("vt", 500)
+}
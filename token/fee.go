@@ -0,0 +1,186 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const (
+	FeeExemptCompositeType = "fee_exempt"
+	basisPoints            = 10000
+)
+
+// FeeTier is the fee percentage for amounts starting from the amount
+type FeeTier struct {
+	From *big.Int `json:"from"`
+	Fee  *big.Int `json:"fee"`
+}
+
+// FeeSplit is the share of the fee in basis points paid to the address
+type FeeSplit struct {
+	Address     *types.Address `json:"address"`
+	BasisPoints uint32         `json:"basis_points"` //nolint:tagliatelle
+}
+
+// FeeLeg is the part of the fee paid to the address
+type FeeLeg struct {
+	Address *types.Address `json:"address"`
+	Fee     *big.Int       `json:"fee"`
+}
+
+// TxSetFeeTiers sets fee percentages by amount brackets, rawTiers is a json array of FeeTier.
+// The fee of the highest tier which amount isn't greater than the transfer is used instead of the fee,
+// floor and cap are applied as usual. Empty array removes tiers.
+func (bt *BaseToken) TxSetFeeTiers(sender *types.Sender, rawTiers string) error {
+	if !sender.Equal(bt.FeeSetter()) {
+		return errors.New("unauthorized")
+	}
+	var tiers []*FeeTier
+	if err := json.Unmarshal([]byte(rawTiers), &tiers); err != nil {
+		return err
+	}
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return err
+	}
+	if bt.config.Fee == nil {
+		return errors.New("fee is not set")
+	}
+	for _, tier := range tiers {
+		if tier == nil || tier.From == nil || tier.Fee == nil || tier.From.Sign() < 0 || tier.Fee.Sign() < 0 {
+			return errors.New("incorrect tier")
+		}
+		if tier.Fee.Cmp(new(big.Int).SetInt64(100000000)) > 0 { //nolint:gomnd
+			return errors.New("fee should be equal or less than 100%")
+		}
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		return tiers[i].From.Cmp(tiers[j].From) < 0
+	})
+	bt.config.Fee.Tiers = make([]*proto.TokenFeeTier, 0, len(tiers))
+	for i, tier := range tiers {
+		if i > 0 && tier.From.Cmp(tiers[i-1].From) == 0 {
+			return errors.New("duplicate tier")
+		}
+		bt.config.Fee.Tiers = append(bt.config.Fee.Tiers, &proto.TokenFeeTier{
+			From: tier.From.Bytes(),
+			Fee:  tier.Fee.Bytes(),
+		})
+	}
+	return bt.saveConfig()
+}
+
+// feeTierRate returns the fee percentage of the tier of the amount, or the fee of the config without tiers
+func feeTierRate(config *proto.TokenFee, amount *big.Int) *big.Int {
+	rate := new(big.Int).SetBytes(config.Fee)
+	for _, tier := range config.Tiers {
+		if new(big.Int).SetBytes(tier.From).Cmp(amount) > 0 {
+			break
+		}
+		rate = new(big.Int).SetBytes(tier.Fee)
+	}
+	return rate
+}
+
+// TxSetFeeExempt exempts the address from fees or removes the exemption
+func (bt *BaseToken) TxSetFeeExempt(sender *types.Sender, address *types.Address, exempt bool) error {
+	if !sender.Equal(bt.FeeSetter()) {
+		return errors.New("unauthorized")
+	}
+	key, err := bt.GetStub().CreateCompositeKey(FeeExemptCompositeType, []string{address.String()})
+	if err != nil {
+		return err
+	}
+	if !exempt {
+		return bt.GetStub().DelState(key)
+	}
+	return bt.GetStub().PutState(key, []byte{1})
+}
+
+// QueryFeeExempt returns true if the address doesn't pay fees
+func (bt *BaseToken) QueryFeeExempt(address *types.Address) (bool, error) {
+	key, err := bt.GetStub().CreateCompositeKey(FeeExemptCompositeType, []string{address.String()})
+	if err != nil {
+		return false, err
+	}
+	data, err := bt.GetStub().GetState(key)
+	if err != nil {
+		return false, err
+	}
+	return len(data) != 0, nil
+}
+
+// feeExemptApply returns zero fee if the payer is exempt from fees
+func (bt *BaseToken) feeExemptApply(payer *types.Address, fee *Predict) (*types.Address, *Predict, error) {
+	exempt, err := bt.QueryFeeExempt(payer)
+	if err != nil {
+		return nil, nil, err
+	}
+	if exempt {
+		return payer, &Predict{Fee: big.NewInt(0), Currency: fee.Currency}, nil
+	}
+	return payer, fee, nil
+}
+
+// TxSetFeeSplits sets recipients of shares of the fee, rawSplits is a json array of FeeSplit.
+// The rest of the fee goes to the fee address. Empty array removes splits.
+func (bt *BaseToken) TxSetFeeSplits(sender *types.Sender, rawSplits string) error {
+	if !sender.Equal(bt.FeeAddressSetter()) {
+		return errors.New("unauthorized")
+	}
+	var splits []*FeeSplit
+	if err := json.Unmarshal([]byte(rawSplits), &splits); err != nil {
+		return err
+	}
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return err
+	}
+	total := uint32(0)
+	bt.config.FeeSplits = make([]*proto.FeeSplit, 0, len(splits))
+	for _, split := range splits {
+		if split == nil || split.Address == nil || split.BasisPoints == 0 {
+			return errors.New("incorrect split")
+		}
+		// addresses in json are not checked by the contract like arguments of the method
+		if _, err := split.Address.PrepareToSave(bt.GetStub(), split.Address.String()); err != nil {
+			return err
+		}
+		total += split.BasisPoints
+		if total > basisPoints {
+			return errors.New("splits should be equal or less than 10000 basis points")
+		}
+		bt.config.FeeSplits = append(bt.config.FeeSplits, &proto.FeeSplit{
+			Address:     split.Address.Bytes(),
+			BasisPoints: split.BasisPoints,
+		})
+	}
+	return bt.saveConfig()
+}
+
+// feeLegs splits the fee among split recipients, the rest including rounding goes to the fee address
+func (bt *BaseToken) feeLegs(fee *big.Int) []*FeeLeg {
+	if fee.Sign() == 0 {
+		return nil
+	}
+	legs := make([]*FeeLeg, 0, len(bt.config.FeeSplits)+1)
+	rest := new(big.Int).Set(fee)
+	for _, split := range bt.config.FeeSplits {
+		share := new(big.Int).Div(
+			new(big.Int).Mul(fee, new(big.Int).SetUint64(uint64(split.BasisPoints))),
+			new(big.Int).SetUint64(basisPoints),
+		)
+		if share.Sign() == 0 {
+			continue
+		}
+		legs = append(legs, &FeeLeg{Address: types.AddrFromBytes(split.Address), Fee: share})
+		rest.Sub(rest, share)
+	}
+	if rest.Sign() > 0 {
+		legs = append(legs, &FeeLeg{Address: types.AddrFromBytes(bt.config.FeeAddress), Fee: rest})
+	}
+	return legs
+}
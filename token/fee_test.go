@@ -0,0 +1,86 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	ma "github.com/tickets-dao/foundation/v3/mock"
+)
+
+func TestFeeTiersExemptAndSplits(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	feeAddressSetter := mock.NewWallet()
+	feeSetter := mock.NewWallet()
+	feeAggregator := mock.NewWallet()
+	partner := mock.NewWallet()
+	user := mock.NewWallet()
+	exempt := mock.NewWallet()
+	receiver := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address(), feeSetter.Address(), feeAddressSetter.Address())
+
+	user.AddBalance("vt", 100000)
+	exempt.AddBalance("vt", 100000)
+
+	feeAddressSetter.SignedInvoke("vt", "setFeeAddress", feeAggregator.Address())
+	// 1% by default, 0.5% from 10000 and 0.1% from 50000
+	feeSetter.SignedInvoke("vt", "setFee", "VT", "1000000", "0", "0")
+	err := user.RawSignedInvokeWithErrorReturned("vt", "setFeeTiers", `[]`)
+	assert.EqualError(t, err, "unauthorized")
+	err = feeSetter.RawSignedInvokeWithErrorReturned("vt", "setFeeTiers", `[{"fee":"100000"},{"from":"10000","fee":"500000"}]`)
+	assert.EqualError(t, err, "incorrect tier")
+	feeSetter.SignedInvoke("vt", "setFeeTiers", `[{"from":"50000","fee":"100000"},{"from":"10000","fee":"500000"}]`)
+
+	predict := &Predict{}
+	for amount, fee := range map[string]int64{"5000": 50, "10000": 50, "20000": 100, "60000": 60} {
+		assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "predictFee", user.Address(), amount)), predict))
+		assert.Equal(t, big.NewInt(fee), predict.Fee, amount)
+	}
+
+	err = feeSetter.RawSignedInvokeWithErrorReturned("vt", "setFeeSplits", `[]`)
+	assert.EqualError(t, err, "unauthorized")
+	err = feeAddressSetter.RawSignedInvokeWithErrorReturned("vt", "setFeeSplits",
+		fmt.Sprintf(`[{"address":"%s","basis_points":10001}]`, partner.Address()))
+	assert.EqualError(t, err, "splits should be equal or less than 10000 basis points")
+	feeAddressSetter.SignedInvoke("vt", "setFeeSplits", fmt.Sprintf(`[{"address":"%s","basis_points":3000}]`, partner.Address()))
+
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "predictFee", user.Address(), "20000")), predict))
+	assert.Len(t, predict.Legs, 2)
+	assert.Equal(t, partner.Address(), predict.Legs[0].Address.String())
+	assert.Equal(t, big.NewInt(30), predict.Legs[0].Fee)
+	assert.Equal(t, feeAggregator.Address(), predict.Legs[1].Address.String())
+	assert.Equal(t, big.NewInt(70), predict.Legs[1].Fee)
+
+	_, resp := user.BatchedInvoke("vt", "transfer", user.SignArgs("vt", "transfer", receiver.Address(), "20000", "")...)
+	assert.Empty(t, resp.Error)
+	assert.Len(t, resp.Accounting, 3)
+	user.BalanceShouldBe("vt", 79900)
+	partner.BalanceShouldBe("vt", 30)
+	feeAggregator.BalanceShouldBe("vt", 70)
+
+	feeSetter.SignedInvoke("vt", "setFeeExempt", exempt.Address(), "true")
+	assert.Equal(t, "true", user.Invoke("vt", "feeExempt", exempt.Address()))
+	exemptPredict := &Predict{}
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "predictFee", exempt.Address(), "20000")), exemptPredict))
+	assert.Equal(t, "0", exemptPredict.Fee.String())
+	assert.Empty(t, exemptPredict.Legs)
+	exempt.SignedInvoke("vt", "transfer", receiver.Address(), "20000", "")
+	exempt.BalanceShouldBe("vt", 80000)
+	feeAggregator.BalanceShouldBe("vt", 70)
+
+	feeSetter.SignedInvoke("vt", "setFeeExempt", exempt.Address(), "false")
+	exempt.SignedInvoke("vt", "transfer", receiver.Address(), "20000", "")
+	exempt.BalanceShouldBe("vt", 59900)
+}
@@ -18,16 +18,20 @@ type Metadata struct {
 	Issuer          string          `json:"issuer"`
 	Methods         []string        `json:"methods"`
 	TotalEmission   *big.Int        `json:"total_emission"` //nolint:tagliatelle
+	MaxSupply       *big.Int        `json:"max_supply"`     //nolint:tagliatelle
 	Fee             *Fee            `json:"fee"`
 	Rates           []*MetadataRate `json:"rates"`
 }
 
 type MetadataRate struct {
-	DealType string   `json:"deal_type"` //nolint:tagliatelle
-	Currency string   `json:"currency"`
-	Rate     *big.Int `json:"rate"`
-	Min      *big.Int `json:"min"`
-	Max      *big.Int `json:"max"`
+	DealType  string   `json:"deal_type"` //nolint:tagliatelle
+	Currency  string   `json:"currency"`
+	Rate      *big.Int `json:"rate"`
+	Min       *big.Int `json:"min"`
+	Max       *big.Int `json:"max"`
+	ValidFrom int64    `json:"valid_from,omitempty"` //nolint:tagliatelle
+	ValidTo   int64    `json:"valid_to,omitempty"`   //nolint:tagliatelle
+	Version   uint64   `json:"version,omitempty"`
 }
 
 type Fee struct {
@@ -51,6 +55,7 @@ func (bt *BaseToken) QueryMetadata() (*Metadata, error) {
 		Issuer:          bt.Issuer().String(),
 		Methods:         bt.GetMethods(),
 		TotalEmission:   new(big.Int).SetBytes(bt.config.TotalEmission),
+		MaxSupply:       new(big.Int).SetBytes(bt.config.MaxSupply),
 		Fee:             &Fee{},
 	}
 	if types.IsValidAddressLen(bt.config.FeeAddress) {
@@ -64,11 +69,14 @@ func (bt *BaseToken) QueryMetadata() (*Metadata, error) {
 	}
 	for _, r := range bt.config.Rates {
 		m.Rates = append(m.Rates, &MetadataRate{
-			DealType: r.DealType,
-			Currency: r.Currency,
-			Rate:     new(big.Int).SetBytes(r.Rate),
-			Min:      new(big.Int).SetBytes(r.Min),
-			Max:      new(big.Int).SetBytes(r.Max),
+			DealType:  r.DealType,
+			Currency:  r.Currency,
+			Rate:      new(big.Int).SetBytes(r.Rate),
+			Min:       new(big.Int).SetBytes(r.Min),
+			Max:       new(big.Int).SetBytes(r.Max),
+			ValidFrom: r.ValidFrom,
+			ValidTo:   r.ValidTo,
+			Version:   r.Version,
 		})
 	}
 	return m, nil
@@ -119,22 +127,30 @@ func (bt *BaseToken) TxSetRate(sender *types.Sender, dealType string, currency s
 	if bt.Symbol == currency {
 		return errors.New("currency is equals token: it is impossible")
 	}
-	if err := bt.loadConfigUnlessLoaded(); err != nil {
+	if err := bt.ratesLoad(); err != nil {
 		return err
 	}
+	// the rate without window is used when there are no scheduled rates for the time
 	for i, r := range bt.config.Rates {
-		if r.DealType == dealType && r.Currency == currency {
+		if r.DealType == dealType && r.Currency == currency && r.ValidFrom == 0 && r.ValidTo == 0 {
 			bt.config.Rates[i].Rate = rate.Bytes()
+			if err := bt.rateHistoryAdd(bt.config.Rates[i], RateActionSet); err != nil {
+				return err
+			}
 			return bt.saveConfig()
 		}
 	}
-	bt.config.Rates = append(bt.config.Rates, &proto.TokenRate{
+	r := &proto.TokenRate{
 		DealType: dealType,
 		Currency: currency,
 		Rate:     rate.Bytes(),
 		Max:      new(big.Int).SetUint64(0).Bytes(), // todo maybe needs different solution
 		Min:      new(big.Int).SetUint64(0).Bytes(),
-	})
+	}
+	if err := bt.rateHistoryAdd(r, RateActionSet); err != nil {
+		return err
+	}
+	bt.config.Rates = append(bt.config.Rates, r)
 	return bt.saveConfig()
 }
 
@@ -146,26 +162,45 @@ func (bt *BaseToken) TxSetLimits(sender *types.Sender, dealType string, currency
 	if min.Cmp(max) > 0 && max.Cmp(big.NewInt(0)) > 0 {
 		return errors.New("min limit is greater than max limit")
 	}
-	if err := bt.loadConfigUnlessLoaded(); err != nil {
+	if err := bt.ratesLoad(); err != nil {
 		return err
 	}
 	unknownDealType := true
+	found := false
+	// limits are the same for all windows of the rate
 	for i, r := range bt.config.Rates {
 		if r.DealType == dealType {
 			unknownDealType = false
 			if r.Currency == currency {
 				bt.config.Rates[i].Max = max.Bytes()
 				bt.config.Rates[i].Min = min.Bytes()
-				return bt.saveConfig()
+				if err := bt.rateHistoryAdd(bt.config.Rates[i], RateActionLimits); err != nil {
+					return err
+				}
+				found = true
 			}
 		}
 	}
+	if found {
+		return bt.saveConfig()
+	}
 	if unknownDealType {
 		return fmt.Errorf("unknown DealType. Rate for deal type %s and currency %s was not set", dealType, currency)
 	}
 	return fmt.Errorf("unknown currency. Rate for deal type %s and currency %s was not set", dealType, currency)
 }
 
+// TxSetGivenBalanceLimit sets the limit of value sent to the contract by swaps, zero limit removes it
+func (bt *BaseToken) TxSetGivenBalanceLimit(sender *types.Sender, contract string, limit *big.Int) error {
+	if !sender.Equal(bt.Issuer()) {
+		return errors.New("unauthorized")
+	}
+	if contract == bt.GetID() {
+		return errors.New("contract should be different from the token")
+	}
+	return core.GivenBalanceLimitSet(bt.GetStub(), contract, limit)
+}
+
 // TxDeleteRate - deletes rate from state
 func (bt *BaseToken) TxDeleteRate(sender *types.Sender, dealType string, currency string) error {
 	if !sender.Equal(bt.Issuer()) {
@@ -174,15 +209,23 @@ func (bt *BaseToken) TxDeleteRate(sender *types.Sender, dealType string, currenc
 	if bt.Symbol == currency {
 		return errors.New("currency is equals token: it is impossible")
 	}
-	if err := bt.loadConfigUnlessLoaded(); err != nil {
+	if err := bt.ratesLoad(); err != nil {
 		return err
 	}
-	for i, r := range bt.config.Rates {
-		if r.DealType == dealType && r.Currency == currency {
-			bt.config.Rates = append(bt.config.Rates[:i], bt.config.Rates[i+1:]...)
-			return bt.saveConfig()
+	// scheduled rates of the deal type and currency are deleted as well
+	rates := make([]*proto.TokenRate, 0, len(bt.config.Rates))
+	for _, r := range bt.config.Rates {
+		if r.DealType != dealType || r.Currency != currency {
+			rates = append(rates, r)
+			continue
+		}
+		if err := bt.rateHistoryAdd(r, RateActionDelete); err != nil {
+			return err
 		}
 	}
-
-	return nil
+	if len(rates) == len(bt.config.Rates) {
+		return nil
+	}
+	bt.config.Rates = rates
+	return bt.saveConfig()
 }
@@ -0,0 +1,187 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const (
+	RateHistoryCompositeType = "rate_history"
+
+	RateActionSet     = "set"
+	RateActionLimits  = "limits"
+	RateActionDelete  = "delete"
+	RateActionMigrate = "migrate"
+)
+
+// RateHistoryItem is a version of the rate
+type RateHistoryItem struct {
+	Version   uint64   `json:"version"`
+	Action    string   `json:"action"`
+	Timestamp int64    `json:"timestamp"`
+	Rate      *big.Int `json:"rate"`
+	Min       *big.Int `json:"min"`
+	Max       *big.Int `json:"max"`
+	ValidFrom int64    `json:"valid_from"` //nolint:tagliatelle
+	ValidTo   int64    `json:"valid_to"`   //nolint:tagliatelle
+}
+
+// TxSetRateWindow schedules the rate for the window from validFrom till validTo in unix seconds.
+// Zero validFrom means the time of the transaction and zero validTo means the window isn't limited.
+// While the window lasts the rate overrides the rate set by TxSetRate and windows started earlier.
+func (bt *BaseToken) TxSetRateWindow(
+	sender *types.Sender,
+	dealType string,
+	currency string,
+	rate *big.Int,
+	validFrom int64,
+	validTo int64,
+) error {
+	if !sender.Equal(bt.Issuer()) {
+		return errors.New("unauthorized")
+	}
+	if rate.Sign() <= 0 {
+		return errors.New("trying to set rate = 0")
+	}
+	if bt.Symbol == currency {
+		return errors.New("currency is equals token: it is impossible")
+	}
+	ts, err := bt.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	if validFrom == 0 {
+		validFrom = ts.Seconds
+	}
+	if validTo != 0 && validTo <= validFrom {
+		return errors.New("rate window should end after it starts")
+	}
+	if validTo != 0 && validTo <= ts.Seconds {
+		return errors.New("rate window is over")
+	}
+	if err = bt.ratesLoad(); err != nil {
+		return err
+	}
+
+	r := &proto.TokenRate{
+		DealType:  dealType,
+		Currency:  currency,
+		Rate:      rate.Bytes(),
+		Max:       new(big.Int).SetUint64(0).Bytes(),
+		Min:       new(big.Int).SetUint64(0).Bytes(),
+		ValidFrom: validFrom,
+		ValidTo:   validTo,
+	}
+	for _, existing := range bt.config.Rates {
+		if existing.DealType == dealType && existing.Currency == currency {
+			if existing.ValidFrom == validFrom {
+				return fmt.Errorf("rate window from %d is already set", validFrom)
+			}
+			r.Min, r.Max = existing.Min, existing.Max
+		}
+	}
+	if err = bt.rateHistoryAdd(r, RateActionSet); err != nil {
+		return err
+	}
+	bt.config.Rates = append(bt.config.Rates, r)
+	return bt.saveConfig()
+}
+
+// QueryRateHistory returns all versions of the rate for the deal type and currency
+func (bt *BaseToken) QueryRateHistory(dealType string, currency string) ([]*RateHistoryItem, error) {
+	iter, err := bt.GetStub().GetStateByPartialCompositeKey(RateHistoryCompositeType, []string{dealType, currency})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	result := make([]*RateHistoryItem, 0)
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		var h proto.TokenRateHistory
+		if err = pb.Unmarshal(kv.Value, &h); err != nil {
+			return nil, err
+		}
+		result = append(result, &RateHistoryItem{
+			Version:   h.Rate.Version,
+			Action:    h.Action,
+			Timestamp: h.Timestamp,
+			Rate:      new(big.Int).SetBytes(h.Rate.Rate),
+			Min:       new(big.Int).SetBytes(h.Rate.Min),
+			Max:       new(big.Int).SetBytes(h.Rate.Max),
+			ValidFrom: h.Rate.ValidFrom,
+			ValidTo:   h.Rate.ValidTo,
+		})
+	}
+	return result, nil
+}
+
+// ratesLoad loads the config before rates are changed or used by the deal. Windows which are over
+// are removed from the config, they are kept in the history only. Rates set before versions were
+// introduced get the version and the history entry, so every version in accounting records is in the history.
+func (bt *BaseToken) ratesLoad() error {
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return err
+	}
+	ts, err := bt.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	changed := false
+	rates := make([]*proto.TokenRate, 0, len(bt.config.Rates))
+	for _, r := range bt.config.Rates {
+		if r.ValidTo != 0 && r.ValidTo <= ts.Seconds {
+			changed = true
+			continue
+		}
+		if r.Version == 0 {
+			if err = bt.rateHistoryAdd(r, RateActionMigrate); err != nil {
+				return err
+			}
+			changed = true
+		}
+		rates = append(rates, r)
+	}
+	if !changed {
+		return nil
+	}
+	bt.config.Rates = rates
+	return bt.saveConfig()
+}
+
+// rateHistoryAdd gives the rate a new version and appends it to the history, the config is saved by the caller
+func (bt *BaseToken) rateHistoryAdd(r *proto.TokenRate, action string) error {
+	ts, err := bt.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+	bt.config.RateVersion++
+	r.Version = bt.config.RateVersion
+	key, err := bt.GetStub().CreateCompositeKey(
+		RateHistoryCompositeType,
+		[]string{r.DealType, r.Currency, fmt.Sprintf("%020d", r.Version)},
+	)
+	if err != nil {
+		return err
+	}
+	data, err := pb.Marshal(&proto.TokenRateHistory{Rate: r, Timestamp: ts.Seconds, Action: action})
+	if err != nil {
+		return err
+	}
+	return bt.GetStub().PutState(key, data)
+}
+
+// rateActive returns true if the window of the rate includes now
+func rateActive(r *proto.TokenRate, now int64) bool {
+	return r.ValidFrom <= now && (r.ValidTo == 0 || now < r.ValidTo)
+}
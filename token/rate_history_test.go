@@ -0,0 +1,137 @@
+package token
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	ma "github.com/tickets-dao/foundation/v3/mock"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+func TestRateWindowAndHistory(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	user := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address())
+
+	issuer.SignedInvoke("vt", "emitToken", "10")
+	issuer.SignedInvoke("vt", "setRate", "buyToken", "usd", "100000000")
+	issuer.SignedInvoke("vt", "setLimits", "buyToken", "usd", "1", "10")
+
+	now := time.Now().Unix()
+	err := issuer.RawSignedInvokeWithErrorReturned("vt", "setRateWindow", "buyToken", "usd", "200000000",
+		strconv.FormatInt(now+100, 10), strconv.FormatInt(now+50, 10))
+	assert.EqualError(t, err, "rate window should end after it starts")
+	err = user.RawSignedInvokeWithErrorReturned("vt", "setRateWindow", "buyToken", "usd", "200000000", "0", "0")
+	assert.EqualError(t, err, "unauthorized")
+
+	// the window scheduled in the future doesn't change the current rate
+	issuer.SignedInvoke("vt", "setRateWindow", "buyToken", "usd", "300000000",
+		strconv.FormatInt(now+3600, 10), "0")
+	// the window started now overrides the rate set without window
+	issuer.SignedInvoke("vt", "setRateWindow", "buyToken", "usd", "200000000",
+		"0", strconv.FormatInt(now+3600, 10))
+
+	user.AddAllowedBalance("vt", "usd", 10)
+	_, resp := user.BatchedInvoke("vt", "buyToken", user.SignArgs("vt", "buyToken", "2", "usd")...)
+	assert.Empty(t, resp.Error)
+	user.AllowedBalanceShouldBe("vt", "usd", 6)
+	user.BalanceShouldBe("vt", 2)
+	assert.Len(t, resp.Accounting, 2)
+	for _, record := range resp.Accounting {
+		assert.Equal(t, uint64(4), record.RateVersion)
+	}
+
+	// limits are changed for all windows
+	issuer.SignedInvoke("vt", "setLimits", "buyToken", "usd", "1", "5")
+
+	var history []*RateHistoryItem
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "rateHistory", "buyToken", "usd")), &history))
+	actions := make([]string, 0, len(history))
+	for i, h := range history {
+		assert.Equal(t, uint64(i+1), h.Version)
+		actions = append(actions, h.Action)
+	}
+	assert.Equal(t, []string{
+		RateActionSet, RateActionLimits, RateActionSet, RateActionSet,
+		RateActionLimits, RateActionLimits, RateActionLimits,
+	}, actions)
+	assert.Equal(t, "200000000", history[3].Rate.String())
+	assert.Equal(t, now+3600, history[3].ValidTo)
+
+	issuer.SignedInvoke("vt", "deleteRate", "buyToken", "usd")
+	err = user.RawSignedInvokeWithErrorReturned("vt", "buyToken", "1", "usd")
+	assert.EqualError(t, err, "impossible to buy for this currency")
+
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "rateHistory", "buyToken", "usd")), &history))
+	assert.Len(t, history, 10)
+	assert.Equal(t, RateActionDelete, history[9].Action)
+}
+
+func TestRateMigrationAndPrune(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	user := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address())
+	issuer.SignedInvoke("vt", "emitToken", "10")
+
+	// the rate set before versions were introduced has no version and no history
+	st := mock.GetStub("vt")
+	config := &proto.Token{}
+	assert.NoError(t, pb.Unmarshal(st.State[metadataKey], config))
+	config.Rates = append(config.Rates, &proto.TokenRate{
+		DealType: "buyToken",
+		Currency: "usd",
+		Rate:     big.NewInt(100000000).Bytes(),
+		Min:      big.NewInt(0).Bytes(),
+		Max:      big.NewInt(0).Bytes(),
+	})
+	data, err := pb.Marshal(config)
+	assert.NoError(t, err)
+	st.State[metadataKey] = data
+
+	user.AddAllowedBalance("vt", "usd", 10)
+	_, resp := user.BatchedInvoke("vt", "buyToken", user.SignArgs("vt", "buyToken", "2", "usd")...)
+	assert.Empty(t, resp.Error)
+	for _, record := range resp.Accounting {
+		assert.Equal(t, uint64(1), record.RateVersion)
+	}
+	var history []*RateHistoryItem
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "rateHistory", "buyToken", "usd")), &history))
+	assert.Len(t, history, 1)
+	assert.Equal(t, RateActionMigrate, history[0].Action)
+
+	// the window which is over is removed by any change of rates
+	now := time.Now().Unix()
+	issuer.SignedInvoke("vt", "setRateWindow", "buyToken", "usd", "200000000", "0", strconv.FormatInt(now+10, 10))
+	mock.AddTime(20 * time.Second)
+	issuer.SignedInvoke("vt", "setLimits", "buyToken", "usd", "1", "5")
+
+	metadata := &Metadata{}
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "metadata")), metadata))
+	assert.Len(t, metadata.Rates, 1)
+	assert.Equal(t, uint64(3), metadata.Rates[0].Version)
+	assert.Equal(t, int64(0), metadata.Rates[0].ValidTo)
+}
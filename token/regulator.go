@@ -0,0 +1,73 @@
+package token
+
+import (
+	"errors"
+
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+)
+
+// Frozen is the freeze of the address in the token
+type Frozen struct {
+	Frozen   bool   `json:"frozen"`
+	Incoming bool   `json:"incoming"`
+	Reason   string `json:"reason"`
+}
+
+// TxFreeze blocks outgoing transfers of the address in the token and incoming ones if incoming is set
+func (bt *BaseToken) TxFreeze(sender *types.Sender, address *types.Address, incoming bool, reasonCode string) error {
+	if !sender.Equal(bt.Issuer()) {
+		return errors.New("unauthorized")
+	}
+	if reasonCode == "" {
+		return errors.New("reason code should be set")
+	}
+	return bt.Freeze(sender.Address(), address, incoming, reasonCode)
+}
+
+// TxUnfreeze removes the freeze of the address in the token
+func (bt *BaseToken) TxUnfreeze(sender *types.Sender, address *types.Address, reasonCode string) error {
+	if !sender.Equal(bt.Issuer()) {
+		return errors.New("unauthorized")
+	}
+	if reasonCode == "" {
+		return errors.New("reason code should be set")
+	}
+	return bt.Unfreeze(sender.Address(), address, reasonCode)
+}
+
+// TxForcedTransfer moves the amount of the token or group of the token (token_group)
+// from the address regardless of the freeze, only the regulator can do it
+func (bt *BaseToken) TxForcedTransfer(
+	sender *types.Sender,
+	from *types.Address,
+	to *types.Address,
+	token string,
+	amount *big.Int,
+	reasonCode string,
+) error {
+	regulator := bt.Regulator()
+	if regulator == nil || !sender.Equal(regulator) {
+		return errors.New("unauthorized")
+	}
+	if reasonCode == "" {
+		return errors.New("reason code should be set")
+	}
+	if from.Equal(to) {
+		return errors.New("impossible operation")
+	}
+	if amount.Sign() <= 0 {
+		return errors.New("amount should be positive")
+	}
+	return bt.ForcedTransfer(sender.Address(), token, from, to, amount, reasonCode)
+}
+
+// QueryFrozen returns the freeze of the address in the token
+func (bt *BaseToken) QueryFrozen(address *types.Address) (*Frozen, error) {
+	f, err := core.FreezeLoad(bt.GetStub(), address)
+	if err != nil || f == nil {
+		return &Frozen{}, err
+	}
+	return &Frozen{Frozen: true, Incoming: f.Incoming, Reason: f.Reason}, nil
+}
@@ -0,0 +1,173 @@
+package token
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	ma "github.com/tickets-dao/foundation/v3/mock"
+	"github.com/tickets-dao/foundation/v3/proto"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestFreezeAndForcedTransfer(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	feeAddressSetter := mock.NewWallet()
+	feeSetter := mock.NewWallet()
+	regulator := mock.NewWallet()
+	user := mock.NewWallet()
+	other := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address(), feeSetter.Address(), feeAddressSetter.Address(), regulator.Address())
+
+	user.AddBalance("vt", 1000)
+	other.AddBalance("vt", 1000)
+
+	err := user.RawSignedInvokeWithErrorReturned("vt", "freeze", other.Address(), "false", "AML-1")
+	assert.EqualError(t, err, "unauthorized")
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "freeze", user.Address(), "false", "")
+	assert.EqualError(t, err, "reason code should be set")
+
+	_, resp := issuer.BatchedInvoke("vt", "freeze", issuer.SignArgs("vt", "freeze", user.Address(), "false", "AML-1")...)
+	assert.Empty(t, resp.Error)
+	assert.Contains(t, resp.Events, core.RegulatorEventName)
+	assert.Equal(t, "freeze: AML-1", resp.Accounting[0].Reason)
+	assert.Equal(t, `{"frozen":true,"incoming":false,"reason":"AML-1"}`, user.Invoke("vt", "frozen", user.Address()))
+
+	err = user.RawSignedInvokeWithErrorReturned("vt", "transfer", other.Address(), "100", "")
+	assert.EqualError(t, err, "address "+user.Address()+" is frozen")
+	err = user.RawSignedInvokeWithErrorReturned("vt", "holdCreate", "order", "VT", "100", other.Address(), "0", "order")
+	assert.EqualError(t, err, "address "+user.Address()+" is frozen")
+	other.SignedInvoke("vt", "transfer", user.Address(), "100", "")
+	user.BalanceShouldBe("vt", 1100)
+
+	issuer.SignedInvoke("vt", "freeze", user.Address(), "true", "AML-2")
+	err = other.RawSignedInvokeWithErrorReturned("vt", "transfer", user.Address(), "100", "")
+	assert.EqualError(t, err, "address "+user.Address()+" is frozen for incoming transfers")
+
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "forcedTransfer", user.Address(), other.Address(), "VT", "600", "COURT-1")
+	assert.EqualError(t, err, "unauthorized")
+
+	_, resp = regulator.BatchedInvoke("vt", "forcedTransfer",
+		regulator.SignArgs("vt", "forcedTransfer", user.Address(), other.Address(), "VT", "600", "COURT-1")...)
+	assert.Empty(t, resp.Error)
+	assert.Len(t, resp.Accounting, 1)
+	assert.Equal(t, "forcedTransfer: COURT-1", resp.Accounting[0].Reason)
+	event := &proto.RegulatorEvent{}
+	assert.NoError(t, pb.Unmarshal(resp.Events[core.RegulatorEventName], event))
+	assert.Equal(t, core.RegulatorActionForced, event.Action)
+	assert.Equal(t, "COURT-1", event.Reason)
+	user.BalanceShouldBe("vt", 500)
+	other.BalanceShouldBe("vt", 1500)
+
+	issuer.SignedInvoke("vt", "unfreeze", user.Address(), "AML-3")
+	user.SignedInvoke("vt", "transfer", other.Address(), "100", "")
+	user.BalanceShouldBe("vt", 400)
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "unfreeze", user.Address(), "AML-3")
+	assert.EqualError(t, err, "address isn't frozen")
+}
+
+func TestFreezeIncomingCredits(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	user := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{SwapMinUserSideTimeout: 600, SwapMaxUserSideTimeout: 3600}, issuer.Address())
+	cc := &BaseToken{
+		Symbol: "CC",
+	}
+	mock.NewChainCode("cc", cc, nil, issuer.Address())
+
+	user.AddBalance("vt", 1000)
+	user.AddAllowedBalance("cc", "VT", 1000)
+	issuer.AddGivenBalance("vt", "CC", 1000)
+	hashed := sha3.Sum256([]byte("123"))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	outgoing := user.SignedInvoke("vt", "swapBeginWithTimeout", "VT", "CC", "100", swapHash, "600")
+	incoming := user.SignedInvoke("cc", "swapBegin", "VT", "VT", "100", swapHash)
+	issuer.SignedInvoke("vt", "freeze", user.Address(), "true", "AML-1")
+
+	err := issuer.RawSignedInvokeWithErrorReturned("vt", "mint", user.Address(), "100")
+	assert.EqualError(t, err, "address "+user.Address()+" is frozen for incoming transfers")
+	err = user.InvokeWithError("vt", "swapDone", incoming, "123")
+	assert.EqualError(t, err, "address "+user.Address()+" is frozen for incoming transfers")
+	user.BalanceShouldBe("vt", 900)
+
+	// the refund returns own funds of the frozen address
+	mock.AddTime(600 * time.Second)
+	user.SignedInvoke("vt", "swapCancel", outgoing)
+	user.BalanceShouldBe("vt", 1000)
+}
+
+func TestFreezeScope(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	feeAddressSetter := mock.NewWallet()
+	feeSetter := mock.NewWallet()
+	regulator := mock.NewWallet()
+	user := mock.NewWallet()
+	other := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address(), feeSetter.Address(), feeAddressSetter.Address(), regulator.Address())
+	cc := &BaseToken{
+		Symbol: "CC",
+	}
+	mock.NewChainCode("cc", cc, nil, issuer.Address())
+
+	user.AddBalance("vt", 1000)
+	user.AddAllowedBalance("vt", "CC", 1000)
+	issuer.AddGivenBalance("cc", "VT", 1000)
+
+	// two regulator actions of one multicall get their own events
+	calls, err := json.Marshal([]core.Call{
+		{Method: "freeze", Args: []string{user.Address(), "true", "AML-1"}},
+		{Method: "freeze", Args: []string{other.Address(), "false", "AML-2"}},
+	})
+	assert.NoError(t, err)
+	_, resp := issuer.BatchedInvoke("vt", "multicall", issuer.SignArgs("vt", "multicall", string(calls))...)
+	assert.Empty(t, resp.Error)
+	event := &proto.RegulatorEvent{}
+	assert.NoError(t, pb.Unmarshal(resp.Events[core.RegulatorEventName], event))
+	assert.Equal(t, user.AddressType().Bytes(), event.Address)
+	assert.NoError(t, pb.Unmarshal(resp.Events[core.RegulatorEventName+"_1"], event))
+	assert.Equal(t, other.AddressType().Bytes(), event.Address)
+
+	// the freeze covers the token of the contract, the allowed balance is frozen by its issuer
+	err = user.RawSignedInvokeWithErrorReturned("vt", "transfer", other.Address(), "100", "")
+	assert.EqualError(t, err, "address "+user.Address()+" is frozen")
+	hashed := sha3.Sum256([]byte("123"))
+	user.SignedInvoke("vt", "swapBegin", "CC", "CC", "100", hex.EncodeToString(hashed[:]))
+	user.AllowedBalanceShouldBe("vt", "CC", 900)
+
+	err = regulator.RawSignedInvokeWithErrorReturned("vt", "forcedTransfer", user.Address(), other.Address(), "CC", "100", "COURT-1")
+	assert.EqualError(t, err, "token CC isn't issued by the contract")
+	regulator.SignedInvoke("vt", "forcedTransfer", user.Address(), other.Address(), "VT", "100", "COURT-1")
+	other.BalanceShouldBe("vt", 100)
+}
@@ -0,0 +1,70 @@
+package token
+
+import (
+	"errors"
+
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+)
+
+// QueryPredictSwapFee returns the fee charged for beginning a swap of the amount
+func (bt *BaseToken) QueryPredictSwapFee(amount *big.Int) (*Predict, error) {
+	return bt.calcSwapFee(amount)
+}
+
+// TxSetSwapFee sets the fee for beginning a swap. The fee is the share of the swapped amount
+// charged in the swapped token, floor and cap are in units of the swapped token as well.
+func (bt *BaseToken) TxSetSwapFee(sender *types.Sender, fee *big.Int, floor *big.Int, cap *big.Int) error {
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return err
+	}
+	if !sender.Equal(bt.FeeSetter()) {
+		return errors.New("unauthorized")
+	}
+	if fee.Cmp(new(big.Int).SetInt64(100000000)) > 0 { //nolint:gomnd
+		return errors.New("fee should be equal or less than 100%")
+	}
+	if cap.Cmp(big.NewInt(0)) > 0 && floor.Cmp(cap) > 0 {
+		return errors.New("incorrect limits")
+	}
+	return bt.setSwapFee(fee, floor, cap)
+}
+
+// SwapFee implements core.SwapFeeCalculator. The fee is the share of the amount of any swapped token,
+// so swaps of allowed tokens don't need rates. Exempt payers don't pay the fee
+// and the fee is split like the transfer fee.
+func (bt *BaseToken) SwapFee(payer *types.Address, token string, amount *big.Int) (*core.SwapFee, error) {
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return nil, err
+	}
+	if bt.config.SwapFee == nil {
+		return nil, nil
+	}
+	fee, err := bt.calcSwapFee(amount)
+	if err != nil {
+		return nil, err
+	}
+	if _, fee, err = bt.feeExemptApply(payer, fee); err != nil {
+		return nil, err
+	}
+	if fee.Fee.Sign() == 0 {
+		return nil, nil
+	}
+	if !types.IsValidAddressLen(bt.config.FeeAddress) {
+		return nil, errors.New("fee address is not set")
+	}
+	legs := bt.feeLegs(fee.Fee)
+	swapFee := &core.SwapFee{Legs: make([]*core.SwapFeeLeg, 0, len(legs))}
+	for _, leg := range legs {
+		swapFee.Legs = append(swapFee.Legs, &core.SwapFeeLeg{Address: leg.Address, Amount: leg.Fee})
+	}
+	return swapFee, nil
+}
+
+func (bt *BaseToken) calcSwapFee(amount *big.Int) (*Predict, error) {
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return &Predict{}, err
+	}
+	return bt.calcFeeByConfig(bt.config.SwapFee, amount)
+}
@@ -0,0 +1,98 @@
+package token
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	ma "github.com/tickets-dao/foundation/v3/mock"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestSwapFee(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	feeAddressSetter := mock.NewWallet()
+	feeSetter := mock.NewWallet()
+	feeAggregator := mock.NewWallet()
+	user := mock.NewWallet()
+	partner := mock.NewWallet()
+	other := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{SwapMinUserSideTimeout: 600, SwapMaxUserSideTimeout: 3600}, issuer.Address(), feeSetter.Address(), feeAddressSetter.Address())
+	cc := &BaseToken{
+		Symbol: "CC",
+	}
+	mock.NewChainCode("cc", cc, nil, issuer.Address())
+
+	user.AddBalance("vt", 1000)
+
+	err := user.RawSignedInvokeWithErrorReturned("vt", "setSwapFee", "1000000", "5", "0")
+	assert.EqualError(t, err, "unauthorized")
+	feeSetter.SignedInvoke("vt", "setSwapFee", "1000000", "5", "0")
+
+	predict := &Predict{}
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "predictSwapFee", "1000")), predict))
+	assert.Equal(t, "VT", predict.Currency)
+	assert.Equal(t, big.NewInt(10), predict.Fee)
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "predictSwapFee", "100")), predict))
+	assert.Equal(t, big.NewInt(5), predict.Fee)
+
+	hashed := sha3.Sum256([]byte("123"))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	err = user.RawSignedInvokeWithErrorReturned("vt", "swapBegin", "VT", "CC", "450", swapHash)
+	assert.EqualError(t, err, "fee address is not set")
+
+	feeAddressSetter.SignedInvoke("vt", "setFeeAddress", feeAggregator.Address())
+	txID := user.SignedInvoke("vt", "swapBeginWithTimeout", "VT", "CC", "450", swapHash, "600")
+	user.BalanceShouldBe("vt", 545)
+	// the fee is kept in the escrow of the swap till it is done
+	feeAggregator.BalanceShouldBe("vt", 0)
+
+	// the fee is refunded even if the fee address is frozen
+	issuer.SignedInvoke("vt", "freeze", feeAggregator.Address(), "true", "AML")
+	mock.AddTime(600 * time.Second)
+	user.SignedInvoke("vt", "swapCancel", txID)
+	user.BalanceShouldBe("vt", 1000)
+	feeAggregator.BalanceShouldBe("vt", 0)
+	issuer.SignedInvoke("vt", "unfreeze", feeAggregator.Address(), "AML")
+
+	// the fee is split and released to its recipients when the swap is done
+	feeAddressSetter.SignedInvoke("vt", "setFeeSplits", fmt.Sprintf(`[{"address":"%s","basis_points":4000}]`, partner.Address()))
+	txID = user.SignedInvoke("vt", "swapBegin", "VT", "CC", "450", swapHash)
+	user.BalanceShouldBe("vt", 545)
+	user.Invoke("cc", "swapDone", txID, "123")
+	user.AllowedBalanceShouldBe("cc", "VT", 450)
+	assert.Nil(t, user.SwapRobotDone("vt", txID, "123").Error)
+	feeAggregator.BalanceShouldBe("vt", 3)
+	partner.BalanceShouldBe("vt", 2)
+
+	// exempt payer doesn't pay the fee
+	feeSetter.SignedInvoke("vt", "setFeeExempt", user.Address(), "true")
+	user.SignedInvoke("vt", "swapBegin", "VT", "CC", "45", swapHash)
+	user.BalanceShouldBe("vt", 500)
+
+	// the fee for another token is charged in it without a rate
+	other.AddAllowedBalance("vt", "CC", 1100)
+	other.AddGivenBalance("cc", "VT", 1100)
+	txID = other.SignedInvoke("vt", "swapBegin", "CC", "CC", "1000", swapHash)
+	other.AllowedBalanceShouldBe("vt", "CC", 90)
+	feeAggregator.AllowedBalanceShouldBe("vt", "CC", 0)
+	other.Invoke("cc", "swapDone", txID, "123")
+	assert.Nil(t, user.SwapRobotDone("vt", txID, "123").Error)
+	feeAggregator.AllowedBalanceShouldBe("vt", "CC", 6)
+	partner.AllowedBalanceShouldBe("vt", "CC", 4)
+}
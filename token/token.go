@@ -13,6 +13,7 @@ import (
 const (
 	FeeSetterArgPos        = 1
 	FeeAddressSetterArgPos = 2
+	RegulatorArgPos        = 3
 	metadataKey            = "tokenMetadata"
 )
 
@@ -29,6 +30,8 @@ type BaseToken struct {
 	Symbol          string
 	Decimals        uint
 	UnderlyingAsset string
+	// TransferBatchMaxRecipients is the maximum number of recipients of one transferBatch, 100 by default
+	TransferBatchMaxRecipients int
 
 	config *proto.Token
 }
@@ -57,6 +60,18 @@ func (bt *BaseToken) FeeAddressSetter() *types.Address {
 	return addr
 }
 
+// Regulator returns the address which can make forced transfers, nil if it's not set in init args
+func (bt *BaseToken) Regulator() *types.Address {
+	if bt.GetInitArgsLen() <= RegulatorArgPos {
+		return nil
+	}
+	addr, err := types.AddrFromBase58Check(bt.GetInitArg(RegulatorArgPos))
+	if err != nil {
+		panic(err)
+	}
+	return addr
+}
+
 func (bt *BaseToken) GetID() string {
 	return bt.Symbol
 }
@@ -91,7 +106,11 @@ func (bt *BaseToken) EmissionAdd(amount *big.Int) error {
 	if bt.config.TotalEmission == nil {
 		bt.config.TotalEmission = new(big.Int).Bytes()
 	}
-	bt.config.TotalEmission = new(big.Int).Add(new(big.Int).SetBytes(bt.config.TotalEmission), amount).Bytes()
+	total := new(big.Int).Add(new(big.Int).SetBytes(bt.config.TotalEmission), amount)
+	if len(bt.config.MaxSupply) != 0 && total.Cmp(new(big.Int).SetBytes(bt.config.MaxSupply)) > 0 {
+		return errors.New("max supply is exceeded")
+	}
+	bt.config.TotalEmission = total.Bytes()
 	return bt.saveConfig()
 }
 
@@ -110,39 +129,69 @@ func (bt *BaseToken) EmissionSub(amount *big.Int) error {
 }
 
 func (bt *BaseToken) setFee(currency string, fee *big.Int, floor *big.Int, cap *big.Int) error {
-	if err := bt.loadConfigUnlessLoaded(); err != nil {
+	tokenFee, err := bt.newTokenFee(currency, fee, floor, cap)
+	if err != nil {
 		return err
 	}
-	if bt.config.Fee == nil {
-		bt.config.Fee = &proto.TokenFee{}
+	// tiers are set separately by TxSetFeeTiers
+	if bt.config.Fee != nil {
+		tokenFee.Tiers = bt.config.Fee.Tiers
+	}
+	bt.config.Fee = tokenFee
+	return bt.saveConfig()
+}
+
+// setSwapFee sets the swap fee, it's calculated in the swapped token, so the token is its currency
+func (bt *BaseToken) setSwapFee(fee *big.Int, floor *big.Int, cap *big.Int) error {
+	tokenFee, err := bt.newTokenFee(bt.Symbol, fee, floor, cap)
+	if err != nil {
+		return err
+	}
+	bt.config.SwapFee = tokenFee
+	return bt.saveConfig()
+}
+
+// newTokenFee checks that the fee can be paid in the currency, it's the token or a currency with a rate
+func (bt *BaseToken) newTokenFee(currency string, fee *big.Int, floor *big.Int, cap *big.Int) (*proto.TokenFee, error) {
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return nil, err
+	}
+	tokenFee := &proto.TokenFee{
+		Currency: currency,
+		Fee:      fee.Bytes(),
+		Floor:    floor.Bytes(),
+		Cap:      cap.Bytes(),
 	}
 	if currency == bt.Symbol {
-		bt.config.Fee.Currency = currency
-		bt.config.Fee.Fee = fee.Bytes()
-		bt.config.Fee.Floor = floor.Bytes()
-		bt.config.Fee.Cap = cap.Bytes()
-		return bt.saveConfig()
+		return tokenFee, nil
 	}
 	for _, rate := range bt.config.Rates {
 		if rate.Currency == currency {
-			bt.config.Fee.Currency = currency
-			bt.config.Fee.Fee = fee.Bytes()
-			bt.config.Fee.Floor = floor.Bytes()
-			bt.config.Fee.Cap = cap.Bytes()
-			return bt.saveConfig()
+			return tokenFee, nil
 		}
 	}
-	return errors.New("unknown currency")
+	return nil, errors.New("unknown currency")
 }
 
 func (bt *BaseToken) GetRateAndLimits(dealType string, currency string) (*proto.TokenRate, bool, error) {
 	if err := bt.loadConfigUnlessLoaded(); err != nil {
 		return nil, false, err
 	}
+	ts, err := bt.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, false, err
+	}
+	// the scheduled rate which window started last overrides others
+	var active *proto.TokenRate
 	for _, r := range bt.config.Rates {
-		if r.DealType == dealType && r.Currency == currency {
-			return r, true, nil
+		if r.DealType == dealType && r.Currency == currency && rateActive(r, ts.Seconds) {
+			if active == nil || r.ValidFrom > active.ValidFrom {
+				active = r
+			}
 		}
 	}
-	return &proto.TokenRate{}, false, nil
+	if active == nil {
+		return &proto.TokenRate{}, false, nil
+	}
+	return active, true, nil
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/tickets-dao/foundation/v3/core/types"
 	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/proto"
 )
 
 const (
@@ -13,7 +14,7 @@ const (
 	RateDecimal = 8
 )
 
-func (bt *BaseToken) TxTransfer(sender *types.Sender, to *types.Address, amount *big.Int, _ string) error { // ref
+func (bt *BaseToken) TxTransfer(sender *types.Sender, to *types.Address, amount *big.Int, ref string) error {
 	if sender.Equal(to) {
 		return errors.New("impossible operation")
 	}
@@ -30,29 +31,114 @@ func (bt *BaseToken) TxTransfer(sender *types.Sender, to *types.Address, amount
 		return errors.New("fee address is not set")
 	}
 
-	if err := bt.TokenBalanceTransfer(sender.Address(), to, amount, "transfer"); err != nil {
-		return err
+	return bt.WithTransferRef(sender.Address(), ref, func() error {
+		if err := bt.TokenBalanceTransfer(sender.Address(), to, amount, "transfer"); err != nil {
+			return err
+		}
+
+		payer, fee, err := bt.feePayer(sender, amount)
+		if err != nil {
+			return err
+		}
+
+		if !sender.Address().IsUserIDSame(to) {
+			return bt.payFee(payer, fee, "transfer fee")
+		}
+
+		return nil
+	})
+}
+
+// payFee transfers the fee from the payer to the fee address and split recipients if the fee is set,
+// every recipient gets a separate accounting record
+func (bt *BaseToken) payFee(payer *types.Address, fee *Predict, reason string) error {
+	if fee.Fee.Cmp(new(big.Int).SetInt64(0)) == 0 {
+		return nil
+	}
+	if types.IsValidAddressLen(bt.config.FeeAddress) && bt.config.Fee != nil && bt.config.Fee.Currency != "" {
+		for _, leg := range bt.feeLegs(fee.Fee) {
+			var err error
+			if fee.Currency == bt.Symbol {
+				err = bt.TokenBalanceTransfer(payer, leg.Address, leg.Fee, reason)
+			} else {
+				err = bt.AllowedBalanceTransfer(fee.Currency, payer, leg.Address, leg.Fee, reason)
+			}
+			if err != nil {
+				return err
+			}
+		}
 	}
+	return nil
+}
 
+// feePayer returns the address which pays the fee for the amount and the fee itself.
+// The fee of a relayed transaction is paid by the relayer in the currency chosen by the relayer.
+func (bt *BaseToken) feePayer(sender *types.Sender, amount *big.Int) (*types.Address, *Predict, error) {
 	fee, err := bt.calcFee(amount)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+	relay := bt.GetRelay()
+	if relay == nil {
+		return bt.feeExemptApply(sender.Address(), fee)
+	}
+	if relay.FeeCurrency == "" || relay.FeeCurrency == fee.Currency {
+		return bt.feeExemptApply(relay.Relayer, fee)
+	}
+	fee, err = bt.convertFee(fee, relay.FeeCurrency)
+	if err != nil {
+		return nil, nil, err
 	}
+	return bt.feeExemptApply(relay.Relayer, fee)
+}
 
-	if !sender.Address().IsUserIDSame(to) && fee.Fee.Cmp(new(big.Int).SetInt64(0)) != 0 {
-		if types.IsValidAddressLen(bt.config.FeeAddress) && bt.config.Fee != nil && bt.config.Fee.Currency != "" {
-			feeAddr := types.AddrFromBytes(bt.config.FeeAddress)
-			if bt.config.Fee.Currency == bt.Symbol {
-				return bt.TokenBalanceTransfer(sender.Address(), feeAddr, fee.Fee, "transfer fee")
-			}
-			return bt.AllowedBalanceTransfer(fee.Currency, sender.Address(), feeAddr, fee.Fee, "transfer fee")
+// convertFee converts the fee to another currency using buyToken rates
+func (bt *BaseToken) convertFee(fee *Predict, currency string) (*Predict, error) {
+	amount := fee.Fee
+	if fee.Currency != bt.Symbol {
+		rate, ok, err := bt.GetRateAndLimits("buyToken", fee.Currency)
+		if err != nil {
+			return &Predict{}, err
+		}
+		if !ok {
+			return &Predict{}, errors.New("incorrect fee currency")
 		}
+		amount = new(big.Int).Div(
+			new(big.Int).Mul(
+				amount,
+				new(big.Int).Exp(
+					new(big.Int).SetUint64(10), //nolint:gomnd
+					new(big.Int).SetUint64(RateDecimal),
+					nil,
+				),
+			),
+			new(big.Int).SetBytes(rate.Rate),
+		)
 	}
-
-	return nil
+	if currency != bt.Symbol {
+		rate, ok, err := bt.GetRateAndLimits("buyToken", currency)
+		if err != nil {
+			return &Predict{}, err
+		}
+		if !ok {
+			return &Predict{}, errors.New("incorrect fee currency")
+		}
+		amount = new(big.Int).Div(
+			new(big.Int).Mul(
+				amount,
+				new(big.Int).SetBytes(rate.Rate),
+			),
+			new(big.Int).Exp(
+				new(big.Int).SetUint64(10), //nolint:gomnd
+				new(big.Int).SetUint64(RateDecimal),
+				nil,
+			),
+		)
+	}
+	return &Predict{Fee: amount, Currency: currency}, nil
 }
 
-func (bt *BaseToken) TxAllowedIndustrialBalanceTransfer(sender *types.Sender, to *types.Address, rawAssets string, _ string) error { // ref
+func (bt *BaseToken) TxAllowedIndustrialBalanceTransfer(sender *types.Sender, to *types.Address, rawAssets string, ref string) error {
 	if sender.Equal(to) {
 		return errors.New("impossible operation")
 	}
@@ -76,16 +162,31 @@ func (bt *BaseToken) TxAllowedIndustrialBalanceTransfer(sender *types.Sender, to
 		}
 	}
 
-	return bt.AllowedIndustrialBalanceTransfer(sender.Address(), to, assets, "transfer")
+	return bt.WithTransferRef(sender.Address(), ref, func() error {
+		return bt.AllowedIndustrialBalanceTransfer(sender.Address(), to, assets, "transfer")
+	})
 }
 
 type Predict struct {
-	Currency string   `json:"currency"`
-	Fee      *big.Int `json:"fee"`
+	Currency string    `json:"currency"`
+	Fee      *big.Int  `json:"fee"`
+	Legs     []*FeeLeg `json:"legs,omitempty"`
 }
 
-func (bt *BaseToken) QueryPredictFee(amount *big.Int) (*Predict, error) {
-	return bt.calcFee(amount)
+// QueryPredictFee returns the fee of the transfer paid by the payer and its breakdown among recipients,
+// the fee of the payer exempt from fees is zero
+func (bt *BaseToken) QueryPredictFee(payer *types.Address, amount *big.Int) (*Predict, error) {
+	predict, err := bt.calcFee(amount)
+	if err != nil {
+		return predict, err
+	}
+	if _, predict, err = bt.feeExemptApply(payer, predict); err != nil {
+		return nil, err
+	}
+	if types.IsValidAddressLen(bt.config.FeeAddress) {
+		predict.Legs = bt.feeLegs(predict.Fee)
+	}
+	return predict, nil
 }
 
 func (bt *BaseToken) TxSetFee(sender *types.Sender, currency string, fee *big.Int, floor *big.Int, cap *big.Int) error {
@@ -120,15 +221,23 @@ func (bt *BaseToken) calcFee(amount *big.Int) (*Predict, error) {
 	if err := bt.loadConfigUnlessLoaded(); err != nil {
 		return &Predict{}, err
 	}
+	return bt.calcFeeByConfig(bt.config.Fee, amount)
+}
 
-	if bt.config.Fee == nil || bt.config.Fee.Fee == nil || new(big.Int).SetBytes(bt.config.Fee.Fee).Cmp(big.NewInt(0)) == 0 {
+// calcFeeByConfig calculates the fee for the amount with the percentage, floor and cap of the config
+func (bt *BaseToken) calcFeeByConfig(config *proto.TokenFee, amount *big.Int) (*Predict, error) {
+	if config == nil {
+		return &Predict{Fee: big.NewInt(0), Currency: bt.Symbol}, nil
+	}
+	rate := feeTierRate(config, amount)
+	if rate.Cmp(big.NewInt(0)) == 0 {
 		return &Predict{Fee: big.NewInt(0), Currency: bt.Symbol}, nil
 	}
 
 	fee := new(big.Int).Div(
 		new(big.Int).Mul(
 			amount,
-			new(big.Int).SetBytes(bt.config.Fee.Fee),
+			rate,
 		),
 		new(big.Int).Exp(
 			new(big.Int).SetUint64(10), //nolint:gomnd
@@ -137,8 +246,8 @@ func (bt *BaseToken) calcFee(amount *big.Int) (*Predict, error) {
 		),
 	)
 
-	if bt.config.Fee.Currency != bt.Symbol {
-		rate, ok, err := bt.GetRateAndLimits("buyToken", bt.config.Fee.Currency)
+	if config.Currency != bt.Symbol {
+		rate, ok, err := bt.GetRateAndLimits("buyToken", config.Currency)
 		if err != nil {
 			return &Predict{}, err
 		}
@@ -159,14 +268,14 @@ func (bt *BaseToken) calcFee(amount *big.Int) (*Predict, error) {
 		)
 	}
 
-	if fee.Cmp(new(big.Int).SetBytes(bt.config.Fee.Floor)) < 0 {
-		fee = new(big.Int).SetBytes(bt.config.Fee.Floor)
+	if fee.Cmp(new(big.Int).SetBytes(config.Floor)) < 0 {
+		fee = new(big.Int).SetBytes(config.Floor)
 	}
 
-	cp := new(big.Int).SetBytes(bt.config.Fee.Cap)
+	cp := new(big.Int).SetBytes(config.Cap)
 	if cp.Cmp(big.NewInt(0)) > 0 && fee.Cmp(cp) > 0 {
-		fee = new(big.Int).SetBytes(bt.config.Fee.Cap)
+		fee = new(big.Int).SetBytes(config.Cap)
 	}
 
-	return &Predict{Fee: fee, Currency: bt.config.Fee.Currency}, nil
+	return &Predict{Fee: fee, Currency: config.Currency}, nil
 }
@@ -0,0 +1,96 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+)
+
+const defaultTransferBatchMaxRecipients = 100
+
+// TransferBatchItem is a leg of the batch transfer
+type TransferBatchItem struct {
+	To     *types.Address `json:"to"`
+	Amount *big.Int       `json:"amount"`
+}
+
+// TxTransferBatch transfers tokens to many recipients in one transaction, rawTransfers is
+// a json array of TransferBatchItem. The fee is calculated for every leg like for the transfer.
+func (bt *BaseToken) TxTransferBatch(sender *types.Sender, rawTransfers string) error {
+	var transfers []*TransferBatchItem
+	if err := json.Unmarshal([]byte(rawTransfers), &transfers); err != nil {
+		return err
+	}
+	if len(transfers) == 0 {
+		return errors.New("no recipients")
+	}
+	maxRecipients := bt.TransferBatchMaxRecipients
+	if maxRecipients == 0 {
+		maxRecipients = defaultTransferBatchMaxRecipients
+	}
+	if len(transfers) > maxRecipients {
+		return fmt.Errorf("too many recipients, maximum is %d", maxRecipients)
+	}
+
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return err
+	}
+
+	if bt.config.Fee != nil && len(bt.config.FeeAddress) == 0 {
+		return errors.New("fee address is not set")
+	}
+
+	total := big.NewInt(0)
+	fees := make([]*Predict, len(transfers))
+	var payer *types.Address
+	for i, transfer := range transfers {
+		if transfer.To == nil || transfer.Amount == nil {
+			return errors.New("recipient and amount should be set")
+		}
+		if sender.Equal(transfer.To) {
+			return errors.New("impossible operation")
+		}
+		if transfer.Amount.Sign() <= 0 {
+			return errors.New("amount should be more than zero")
+		}
+		// addresses in json are not checked by the contract like arguments of the method
+		if _, err := transfer.To.PrepareToSave(bt.GetStub(), transfer.To.String()); err != nil {
+			return err
+		}
+
+		var err error
+		payer, fees[i], err = bt.feePayer(sender, transfer.Amount)
+		if err != nil {
+			return err
+		}
+		total.Add(total, transfer.Amount)
+		if payer.Equal(sender.Address()) && fees[i].Currency == bt.Symbol && !sender.Address().IsUserIDSame(transfer.To) {
+			total.Add(total, fees[i].Fee)
+		}
+	}
+
+	balance, err := bt.TokenBalanceGet(sender.Address())
+	if err != nil {
+		return err
+	}
+	if balance.Cmp(total) < 0 {
+		return errors.New("insufficient funds to process")
+	}
+
+	for i, transfer := range transfers {
+		if err = bt.TokenBalanceTransfer(sender.Address(), transfer.To, transfer.Amount, "transferBatch"); err != nil {
+			return err
+		}
+		if sender.Address().IsUserIDSame(transfer.To) {
+			continue
+		}
+		if err = bt.payFee(payer, fees[i], "transferBatch fee"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
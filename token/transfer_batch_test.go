@@ -0,0 +1,77 @@
+package token
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	ma "github.com/tickets-dao/foundation/v3/mock"
+)
+
+func TestTransferBatch(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	feeAddressSetter := mock.NewWallet()
+	feeSetter := mock.NewWallet()
+	feeAggregator := mock.NewWallet()
+	user := mock.NewWallet()
+	first := mock.NewWallet()
+	second := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:                       vtName,
+			Symbol:                     "VT",
+			Decimals:                   8,
+			TransferBatchMaxRecipients: 2,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address(), feeSetter.Address(), feeAddressSetter.Address())
+
+	user.AddBalance("vt", 1000)
+	feeAddressSetter.SignedInvoke("vt", "setFeeAddress", feeAggregator.Address())
+	feeSetter.SignedInvoke("vt", "setFee", "VT", "1000000", "1", "0")
+
+	transfers := func(items ...string) string {
+		out := "["
+		for i := 0; i < len(items); i += 2 {
+			if i > 0 {
+				out += ","
+			}
+			out += fmt.Sprintf(`{"to":"%s","amount":"%s"}`, items[i], items[i+1])
+		}
+		return out + "]"
+	}
+
+	err := user.RawSignedInvokeWithErrorReturned("vt", "transferBatch",
+		transfers(first.Address(), "1", second.Address(), "1", feeAggregator.Address(), "1"))
+	assert.EqualError(t, err, "too many recipients, maximum is 2")
+
+	// 500 + 5 and 499 + 4 exceed the balance, nothing is transferred
+	err = user.RawSignedInvokeWithErrorReturned("vt", "transferBatch",
+		transfers(first.Address(), "500", second.Address(), "499"))
+	assert.EqualError(t, err, "insufficient funds to process")
+	first.BalanceShouldBe("vt", 0)
+
+	err = user.RawSignedInvokeWithErrorReturned("vt", "transferBatch",
+		transfers(first.Address(), "100", second.Address(), "0"))
+	assert.EqualError(t, err, "amount should be more than zero")
+	first.BalanceShouldBe("vt", 0)
+
+	_, resp := user.BatchedInvoke("vt", "transferBatch",
+		user.SignArgs("vt", "transferBatch", transfers(first.Address(), "500", second.Address(), "200"))...)
+	assert.Empty(t, resp.Error)
+	user.BalanceShouldBe("vt", 293)
+	first.BalanceShouldBe("vt", 500)
+	second.BalanceShouldBe("vt", 200)
+	feeAggregator.BalanceShouldBe("vt", 7)
+
+	legs := 0
+	for _, record := range resp.Accounting {
+		if record.Reason == "transferBatch" {
+			legs++
+		}
+	}
+	assert.Equal(t, 2, legs)
+}
@@ -0,0 +1,63 @@
+package token
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	ma "github.com/tickets-dao/foundation/v3/mock"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+func TestTransferRef(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	user := mock.NewWallet()
+	receiver := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address())
+
+	user.AddBalance("vt", 1000)
+
+	txID, resp := user.BatchedInvoke("vt", "transfer",
+		user.SignArgs("vt", "transfer", receiver.Address(), "100", "invoice-1")...)
+	assert.Empty(t, resp.Error)
+	assert.Len(t, resp.Accounting, 1)
+	assert.Equal(t, "invoice-1", resp.Accounting[0].Ref)
+	assert.Equal(t, []string{"invoice-1"}, resp.Refs)
+
+	// the ref can be used again
+	secondTxID, resp := user.BatchedInvoke("vt", "transfer",
+		user.SignArgs("vt", "transfer", receiver.Address(), "100", "invoice-1")...)
+	assert.Empty(t, resp.Error)
+	user.BalanceShouldBe("vt", 800)
+
+	var transferRefs []*proto.TransferRef
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "transfersByRef", user.Address(), "invoice-1")), &transferRefs))
+	assert.Len(t, transferRefs, 2)
+	txIDs := make([]string, 0, len(transferRefs))
+	for _, transferRef := range transferRefs {
+		txIDs = append(txIDs, transferRef.TxId)
+		assert.Len(t, transferRef.Accounting, 1)
+		assert.Equal(t, "transfer", transferRef.Accounting[0].Reason)
+	}
+	assert.ElementsMatch(t, []string{txID, secondTxID}, txIDs)
+
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "transfersByRef", user.Address(), "invoice-2")), &transferRefs))
+	assert.Empty(t, transferRefs)
+
+	// the ref of another sender and empty ref are not listed
+	receiver.SignedInvoke("vt", "transfer", user.Address(), "10", "invoice-1")
+	user.SignedInvoke("vt", "transfer", receiver.Address(), "10", "")
+	user.BalanceShouldBe("vt", 800)
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "transfersByRef", user.Address(), "invoice-1")), &transferRefs))
+	assert.Len(t, transferRefs, 2)
+}
@@ -71,7 +71,7 @@ func TestTransferWithFee(t *testing.T) {
 	feeSetter.SignedInvoke("vt", "setFee", "VT", "500000", "1", "0")
 
 	predict := &Predict{}
-	rawResp := issuer.Invoke("vt", "predictFee", "100")
+	rawResp := issuer.Invoke("vt", "predictFee", issuer.Address(), "100")
 
 	err := json.Unmarshal([]byte(rawResp), &predict)
 	assert.NoError(t, err)
@@ -136,3 +136,76 @@ func TestAllowedIndustrialBalanceTransfer(t *testing.T) {
 	user.AllowedBalanceShouldBe("vt", ba1, 50000000)
 	user.AllowedBalanceShouldBe("vt", ba2, 100000000)
 }
+
+func TestRelayedTransferWithFee(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	feeAddressSetter := mock.NewWallet()
+	feeSetter := mock.NewWallet()
+	feeAggregator := mock.NewWallet()
+	relayer := mock.NewWallet()
+	user := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address(), feeSetter.Address(), feeAddressSetter.Address())
+
+	issuer.SignedInvoke("vt", "emitToken", "200")
+	issuer.SignedInvoke("vt", "setRate", "buyToken", "usd", "200000000")
+	feeSetter.SignedInvoke("vt", "setFee", "VT", "500000", "1", "0")
+	feeAddressSetter.SignedInvoke("vt", "setFeeAddress", feeAggregator.Address())
+
+	relayer.AddBalance("vt", 5)
+	relayer.AddAllowedBalance("vt", "usd", 5)
+
+	nested := issuer.SignNested("vt", "transfer", user.Address(), "100", "")
+	err := relayer.RawSignedInvokeWithErrorReturned("vt", "relay", nested, "")
+	assert.NoError(t, err)
+
+	issuer.BalanceShouldBe("vt", 100)
+	user.BalanceShouldBe("vt", 100)
+	relayer.BalanceShouldBe("vt", 4)
+	feeAggregator.BalanceShouldBe("vt", 1)
+
+	nested = issuer.SignNested("vt", "transfer", user.Address(), "100", "")
+	err = relayer.RawSignedInvokeWithErrorReturned("vt", "relay", nested, "usd")
+	assert.NoError(t, err)
+
+	issuer.BalanceShouldBe("vt", 0)
+	user.BalanceShouldBe("vt", 200)
+	relayer.AllowedBalanceShouldBe("vt", "usd", 3)
+	feeAggregator.AllowedBalanceShouldBe("vt", "usd", 2)
+}
+
+func TestRelayedTransferWrongCall(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	relayer := mock.NewWallet()
+	user := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address())
+	issuer.SignedInvoke("vt", "emitToken", "100")
+
+	nested := issuer.SignNested("cc", "transfer", user.Address(), "100", "")
+	err := relayer.RawSignedInvokeWithErrorReturned("vt", "relay", nested, "")
+	assert.EqualError(t, err, "incorrect chaincode")
+
+	err = relayer.RawSignedInvokeWithErrorReturned("vt", "relay", relayer.SignNested("vt", "metadata"), "")
+	assert.EqualError(t, err, "method metadata can't be relayed")
+
+	issuer.BalanceShouldBe("vt", 100)
+}
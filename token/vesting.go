@@ -0,0 +1,225 @@
+package token
+
+import (
+	"encoding/hex"
+	"errors"
+
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	"github.com/tickets-dao/foundation/v3/proto"
+)
+
+const (
+	VestingCompositeType = "vesting"
+	VestingReason        = "vesting"
+)
+
+// VestingInfo is the schedule of the vesting with amounts at the moment of the query
+type VestingInfo struct {
+	ID          string   `json:"id"`
+	Beneficiary string   `json:"beneficiary"`
+	Amount      *big.Int `json:"amount"`
+	Vested      *big.Int `json:"vested"`
+	Claimed     *big.Int `json:"claimed"`
+	Remaining   *big.Int `json:"remaining"`
+	Start       int64    `json:"start"`
+	Cliff       int64    `json:"cliff"`
+	Duration    int64    `json:"duration"`
+	Step        int64    `json:"step"`
+}
+
+// TxCreateVesting transfers the amount from the issuer to the vesting balance of the beneficiary until it's vested.
+// Nothing is vested before start + cliff, then the amount is vested linearly over the duration,
+// or by equal parts every step seconds if the step is set. Zero start means the time of the transaction.
+func (bt *BaseToken) TxCreateVesting(
+	sender *types.Sender,
+	beneficiary *types.Address,
+	amount *big.Int,
+	start int64,
+	cliff int64,
+	duration int64,
+	step int64,
+) (string, error) {
+	if !sender.Equal(bt.Issuer()) {
+		return "", errors.New("unauthorized")
+	}
+	if sender.Equal(beneficiary) {
+		return "", errors.New("impossible operation")
+	}
+	if amount.Sign() <= 0 {
+		return "", errors.New("amount should be positive")
+	}
+	if duration <= 0 {
+		return "", errors.New("duration should be positive")
+	}
+	if cliff < 0 || cliff > duration {
+		return "", errors.New("cliff should be within the duration")
+	}
+	if step < 0 || step > duration {
+		return "", errors.New("step should be within the duration")
+	}
+	if start == 0 {
+		ts, err := bt.GetStub().GetTxTimestamp()
+		if err != nil {
+			return "", err
+		}
+		start = ts.Seconds
+	}
+	id, err := hex.DecodeString(bt.GetStub().GetTxID())
+	if err != nil {
+		return "", err
+	}
+	v := &proto.Vesting{
+		Id:          id,
+		Beneficiary: beneficiary.Bytes(),
+		Amount:      amount.Bytes(),
+		Start:       start,
+		Cliff:       cliff,
+		Duration:    duration,
+		Step:        step,
+	}
+	if _, err = VestingLoad(bt.GetStub(), beneficiary, bt.GetStub().GetTxID()); err == nil {
+		return "", errors.New("vesting already exists")
+	}
+	if err = bt.TokenBalanceTransferVesting(sender.Address(), beneficiary, amount, VestingReason); err != nil {
+		return "", err
+	}
+	if err = VestingSave(bt.GetStub(), bt.GetStub().GetTxID(), v); err != nil {
+		return "", err
+	}
+	return bt.GetStub().GetTxID(), nil
+}
+
+// TxClaimVested moves the amount vested by the time of the transaction and not claimed yet
+func (bt *BaseToken) TxClaimVested(sender *types.Sender, vestingID string) (*big.Int, error) {
+	v, err := VestingLoad(bt.GetStub(), sender.Address(), vestingID)
+	if err != nil {
+		return nil, err
+	}
+	ts, err := bt.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	claimable := new(big.Int).Sub(vestingVested(v, ts.Seconds), new(big.Int).SetBytes(v.Claimed))
+	if claimable.Sign() == 0 {
+		return nil, errors.New("nothing to claim")
+	}
+	if err = bt.TokenBalanceUnlockVesting(sender.Address(), claimable); err != nil {
+		return nil, err
+	}
+	v.Claimed = new(big.Int).Add(new(big.Int).SetBytes(v.Claimed), claimable).Bytes()
+	if new(big.Int).SetBytes(v.Claimed).Cmp(new(big.Int).SetBytes(v.Amount)) == 0 {
+		return claimable, VestingDel(bt.GetStub(), sender.Address(), vestingID)
+	}
+	return claimable, VestingSave(bt.GetStub(), vestingID, v)
+}
+
+// QueryVestingBalanceOf returns the amount vested to the address which isn't claimed yet
+func (bt *BaseToken) QueryVestingBalanceOf(address *types.Address) (*big.Int, error) {
+	return bt.TokenBalanceGetVesting(address)
+}
+
+// QueryVestings returns vestings of the beneficiary which are not claimed completely
+func (bt *BaseToken) QueryVestings(beneficiary *types.Address) ([]*VestingInfo, error) {
+	ts, err := bt.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	iter, err := bt.GetStub().GetStateByPartialCompositeKey(VestingCompositeType, []string{beneficiary.String()})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	result := make([]*VestingInfo, 0)
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+		var v proto.Vesting
+		if err = pb.Unmarshal(kv.Value, &v); err != nil {
+			return nil, err
+		}
+		result = append(result, vestingInfo(&v, ts.Seconds))
+	}
+	return result, nil
+}
+
+func vestingInfo(v *proto.Vesting, now int64) *VestingInfo {
+	amount := new(big.Int).SetBytes(v.Amount)
+	return &VestingInfo{
+		ID:          hex.EncodeToString(v.Id),
+		Beneficiary: types.AddrFromBytes(v.Beneficiary).String(),
+		Amount:      amount,
+		Vested:      vestingVested(v, now),
+		Claimed:     new(big.Int).SetBytes(v.Claimed),
+		Remaining:   new(big.Int).Sub(amount, new(big.Int).SetBytes(v.Claimed)),
+		Start:       v.Start,
+		Cliff:       v.Cliff,
+		Duration:    v.Duration,
+		Step:        v.Step,
+	}
+}
+
+// vestingVested returns the amount vested by now
+func vestingVested(v *proto.Vesting, now int64) *big.Int {
+	amount := new(big.Int).SetBytes(v.Amount)
+	elapsed := now - v.Start
+	switch {
+	case elapsed < v.Cliff || elapsed <= 0:
+		return big.NewInt(0)
+	case elapsed >= v.Duration:
+		return amount
+	case v.Step > 0:
+		elapsed -= elapsed % v.Step
+	}
+	return new(big.Int).Div(new(big.Int).Mul(amount, big.NewInt(elapsed)), big.NewInt(v.Duration))
+}
+
+func vestingKey(stub shim.ChaincodeStubInterface, beneficiary *types.Address, vestingID string) (string, error) {
+	return stub.CreateCompositeKey(VestingCompositeType, []string{beneficiary.String(), vestingID})
+}
+
+func VestingLoad(stub shim.ChaincodeStubInterface, beneficiary *types.Address, vestingID string) (*proto.Vesting, error) {
+	key, err := vestingKey(stub, beneficiary, vestingID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("vesting doesn't exist")
+	}
+	var v proto.Vesting
+	if err = pb.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func VestingSave(stub shim.ChaincodeStubInterface, vestingID string, v *proto.Vesting) error {
+	key, err := vestingKey(stub, types.AddrFromBytes(v.Beneficiary), vestingID)
+	if err != nil {
+		return err
+	}
+	data, err := pb.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(key, data)
+}
+
+func VestingDel(stub shim.ChaincodeStubInterface, beneficiary *types.Address, vestingID string) error {
+	key, err := vestingKey(stub, beneficiary, vestingID)
+	if err != nil {
+		return err
+	}
+	return stub.DelState(key)
+}
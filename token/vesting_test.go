@@ -0,0 +1,127 @@
+package token
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tickets-dao/foundation/v3/core"
+	"github.com/tickets-dao/foundation/v3/core/types"
+	"github.com/tickets-dao/foundation/v3/core/types/big"
+	ma "github.com/tickets-dao/foundation/v3/mock"
+)
+
+func TestVesting(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	user := mock.NewWallet()
+
+	vt := &VT{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", vt, &core.ContractOptions{}, issuer.Address())
+
+	issuer.AddBalance("vt", 3000)
+	now := time.Now().Unix()
+
+	err := user.RawSignedInvokeWithErrorReturned("vt", "createVesting", user.Address(), "1000", "0", "0", "100", "0")
+	assert.EqualError(t, err, "unauthorized")
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "createVesting", user.Address(), "1000", "0", "200", "100", "0")
+	assert.EqualError(t, err, "cliff should be within the duration")
+
+	// stepwise schedule in the middle of the third step of 25 seconds
+	stepwise := issuer.SignedInvoke("vt", "createVesting", user.Address(), "1000",
+		strconv.FormatInt(now-60, 10), "20", "100", "25")
+	// cliff is not passed yet
+	cliff := issuer.SignedInvoke("vt", "createVesting", user.Address(), "1000", "0", "50", "100", "0")
+	// linear schedule which is over
+	linear := issuer.SignedInvoke("vt", "createVesting", user.Address(), "1000",
+		strconv.FormatInt(now-200, 10), "0", "100", "0")
+	issuer.BalanceShouldBe("vt", 0)
+	user.BalanceShouldBe("vt", 0)
+
+	var vestings []*VestingInfo
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "vestings", user.Address())), &vestings))
+	assert.Len(t, vestings, 3)
+	for _, v := range vestings {
+		switch v.ID {
+		case stepwise:
+			assert.Equal(t, big.NewInt(500), v.Vested)
+		case cliff:
+			assert.Equal(t, big.NewInt(0), v.Vested)
+		case linear:
+			assert.Equal(t, big.NewInt(1000), v.Vested)
+		default:
+			assert.Fail(t, "unknown vesting "+v.ID)
+		}
+	}
+
+	user.SignedInvoke("vt", "claimVested", stepwise)
+	user.BalanceShouldBe("vt", 500)
+	err = user.RawSignedInvokeWithErrorReturned("vt", "claimVested", stepwise)
+	assert.EqualError(t, err, "nothing to claim")
+	err = user.RawSignedInvokeWithErrorReturned("vt", "claimVested", cliff)
+	assert.EqualError(t, err, "nothing to claim")
+	err = issuer.RawSignedInvokeWithErrorReturned("vt", "claimVested", linear)
+	assert.EqualError(t, err, "vesting doesn't exist")
+
+	user.SignedInvoke("vt", "claimVested", linear)
+	user.BalanceShouldBe("vt", 1500)
+
+	assert.NoError(t, json.Unmarshal([]byte(user.Invoke("vt", "vestings", user.Address())), &vestings))
+	assert.Len(t, vestings, 2)
+	for _, v := range vestings {
+		if v.ID == stepwise {
+			assert.Equal(t, big.NewInt(500), v.Claimed)
+			assert.Equal(t, big.NewInt(500), v.Remaining)
+		}
+	}
+}
+
+type twoVestingsToken struct {
+	BaseToken
+}
+
+func (tt *twoVestingsToken) TxCreateTwoVestings(sender *types.Sender, beneficiary *types.Address, amount *big.Int) error {
+	if _, err := tt.TxCreateVesting(sender, beneficiary, amount, 0, 0, 100, 0); err != nil {
+		return err
+	}
+	_, err := tt.TxCreateVesting(sender, beneficiary, amount, 0, 0, 100, 0)
+	return err
+}
+
+func (tt *twoVestingsToken) QueryLockedBalanceOf(address *types.Address) (*big.Int, error) {
+	return tt.TokenBalanceGetLocked(address)
+}
+
+func TestVestingBalance(t *testing.T) {
+	mock := ma.NewLedger(t)
+	issuer := mock.NewWallet()
+	user := mock.NewWallet()
+
+	tt := &twoVestingsToken{
+		BaseToken{
+			Name:     vtName,
+			Symbol:   "VT",
+			Decimals: 8,
+		},
+	}
+	mock.NewChainCode("vt", tt, &core.ContractOptions{}, issuer.Address())
+
+	issuer.AddBalance("vt", 3000)
+	err := issuer.RawSignedInvokeWithErrorReturned("vt", "createTwoVestings", user.Address(), "1000")
+	assert.EqualError(t, err, "vesting already exists")
+	issuer.BalanceShouldBe("vt", 3000)
+
+	issuer.SignedInvoke("vt", "createVesting", user.Address(), "1000", "0", "0", "100", "0")
+	issuer.BalanceShouldBe("vt", 2000)
+	user.BalanceShouldBe("vt", 0)
+	assert.Equal(t, "\"1000\"", user.Invoke("vt", "vestingBalanceOf", user.Address()))
+	assert.Equal(t, "\"0\"", user.Invoke("vt", "lockedBalanceOf", user.Address()))
+}